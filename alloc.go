@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"unsafe"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+type (
+	// AllocTracker reports the allocations made between Begin and End, as a
+	// line of text or as a json result for -format json
+	AllocTracker struct {
+		w      io.Writer
+		format string
+		op     string
+		start  runtime.MemStats
+	}
+
+	StructSize struct {
+		Name  string
+		Bytes uintptr
+	}
+)
+
+func NewAllocTracker(w io.Writer, format string) *AllocTracker {
+	return &AllocTracker{
+		w:      w,
+		format: format,
+	}
+}
+
+func (t *AllocTracker) Begin(op string) {
+	if t == nil {
+		return
+	}
+	t.op = op
+	runtime.ReadMemStats(&t.start)
+}
+
+func (t *AllocTracker) End(sizes ...StructSize) {
+	if t == nil {
+		return
+	}
+	var end runtime.MemStats
+	runtime.ReadMemStats(&end)
+	res := wordle.AllocResult{
+		Alloc:  t.op,
+		Allocs: end.Mallocs - t.start.Mallocs,
+		Bytes:  end.TotalAlloc - t.start.TotalAlloc,
+	}
+	if len(sizes) > 0 {
+		res.Sizes = make(map[string]uint64, len(sizes))
+		for _, v := range sizes {
+			res.Sizes[v.Name] = uint64(v.Bytes)
+		}
+	}
+	if t.format == "json" {
+		json.NewEncoder(t.w).Encode(res)
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "alloc %s: %d allocs %d bytes", res.Alloc, res.Allocs, res.Bytes)
+	for _, v := range sizes {
+		fmt.Fprintf(&b, " %s=%d", v.Name, v.Bytes)
+	}
+	fmt.Fprintln(t.w, b.String())
+}
+
+func wordsSize(words []wordle.WordleWord) StructSize {
+	return StructSize{
+		Name:  "words",
+		Bytes: uintptr(cap(words)) * unsafe.Sizeof(wordle.WordleWord{}),
+	}
+}
+
+func candidatesSize(universe wordle.Universe) StructSize {
+	return StructSize{
+		Name:  "bitset",
+		Bytes: uintptr(universe.CandidateBytes()),
+	}
+}
+
+// patternsSize is the size of tables of pattern counts for words of length
+// n, where scoring fills one table per worker
+func patternsSize(n int, tables int) StructSize {
+	return StructSize{
+		Name:  "patterns",
+		Bytes: uintptr(wordle.PatternCount(n)*tables) * unsafe.Sizeof(int(0)),
+	}
+}
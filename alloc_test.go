@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func TestAllocTracker(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "abide", "crane", "speed")
+	universe := wordle.NewUniverse(words)
+	sizes := []StructSize{wordsSize(words), candidatesSize(universe), patternsSize(universe.Len(), 2)}
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+
+		var b bytes.Buffer
+		tracker := NewAllocTracker(&b, "text")
+		tracker.Begin("s")
+		tracker.End(sizes...)
+		if !regexp.MustCompile(`^alloc s: \d+ allocs \d+ bytes words=\d+ bitset=8 patterns=3888\n$`).Match(b.Bytes()) {
+			t.Fatalf("unexpected report %q", b.String())
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var b bytes.Buffer
+		tracker := NewAllocTracker(&b, "json")
+		tracker.Begin("s")
+		tracker.End(sizes...)
+		var res wordle.AllocResult
+		if err := json.Unmarshal(b.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		if res.Alloc != "s" {
+			t.Fatalf("expected alloc s, got %q", res.Alloc)
+		}
+		for _, v := range sizes {
+			if got, ok := res.Sizes[v.Name]; !ok || got != uint64(v.Bytes) {
+				t.Fatalf("expected %s=%d, got %v", v.Name, v.Bytes, res.Sizes)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		var tracker *AllocTracker
+		tracker.Begin("s")
+		tracker.End(sizes...)
+	})
+}
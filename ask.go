@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+var ErrAskQuestion = errors.New("Error question must be \"contains <letter>\" or \"pos<n> <letter>\"")
+
+type (
+	AskQuestion struct {
+		Pos    int
+		Letter uint32
+	}
+)
+
+// ParseAskQuestion parses a question about a word of length letters
+func ParseAskQuestion(s string, length int) (AskQuestion, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) != 2 {
+		return AskQuestion{}, ErrAskQuestion
+	}
+	letter, err := wordle.ParseCharSet(fields[1])
+	if err != nil {
+		return AskQuestion{}, err
+	}
+	if len(fields[1]) != 1 {
+		return AskQuestion{}, ErrAskQuestion
+	}
+	if fields[0] == "contains" {
+		return AskQuestion{
+			Pos:    -1,
+			Letter: letter,
+		}, nil
+	}
+	posStr, ok := strings.CutPrefix(fields[0], "pos")
+	if !ok {
+		return AskQuestion{}, ErrAskQuestion
+	}
+	pos, err := strconv.Atoi(posStr)
+	if err != nil || pos < 1 || pos > length {
+		return AskQuestion{}, fmt.Errorf("%w: position must be 1-%d", ErrAskQuestion, length)
+	}
+	return AskQuestion{
+		Pos:    pos - 1,
+		Letter: letter,
+	}, nil
+}
+
+func (q AskQuestion) Answer(target wordle.WordleWord) bool {
+	if q.Pos < 0 {
+		return target.CharSet()&q.Letter != 0
+	}
+	return target[q.Pos] == q.Letter
+}
+
+func (q AskQuestion) Apply(universe wordle.Universe, answer bool) wordle.Universe {
+	if q.Pos < 0 {
+		return universe.WithLetter(q.Letter, answer)
+	}
+	return universe.WithPosition(q.Pos, q.Letter, answer)
+}
+
+func AskGame(target wordle.WordleWord, universe wordle.Universe, budget int) {
+	startPossibilities := universe.Count()
+	spent := 0
+	reader := bufio.NewReader(os.Stdin)
+	for spent < budget {
+		fmt.Printf("Ask (%d/%d points): ", spent, budget)
+		line, err := ReadLine(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if errors.Is(err, ErrLineTooLong) || errors.Is(err, ErrLineChar) {
+				log.Println(err)
+				continue
+			}
+			log.Fatalln("Failed reading input")
+		}
+		line = strings.TrimSpace(line)
+		if line == "p" {
+			for _, v := range universe.Candidates() {
+				fmt.Println(v)
+			}
+			continue
+		}
+		if arg, ok := strings.CutPrefix(line, "ask "); ok {
+			q, err := ParseAskQuestion(arg, universe.Len())
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+			spent++
+			answer := q.Answer(target)
+			universe = q.Apply(universe, answer)
+			if answer {
+				fmt.Println("yes")
+			} else {
+				fmt.Println("no")
+			}
+			fmt.Println(universe.Count(), "possibilities")
+			continue
+		}
+		guess, err := wordle.ParseWordLen(line, universe.Len())
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		spent++
+		if guess == target {
+			fmt.Printf("Solved %s with %d/%d points\n", target, spent, budget)
+			printAskEfficiency(startPossibilities, universe.Count(), spent)
+			return
+		}
+		fmt.Println("no")
+	}
+	fmt.Printf("Out of points, the word was %s\n", target)
+}
+
+func printAskEfficiency(startPossibilities, endPossibilities int, spent int) {
+	// a yes/no question yields at most one bit in expectation, and naming the
+	// word costs one more point
+	minimum := int(math.Ceil(wordle.CalcEntropy(startPossibilities))) + 1
+	gained := wordle.CalcEntropy(startPossibilities) - wordle.CalcEntropy(max(endPossibilities, 1))
+	fmt.Printf("Information-theoretic minimum %d points, used %d\n", minimum, spent)
+	if spent > 1 {
+		fmt.Printf("Gained %.2f bits over %d questions, %.2f bits per question\n", gained, spent-1, gained/float64(spent-1))
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAskQuestion(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Question string
+		Length   int
+		Pos      int
+		Err      error
+	}{
+		{Question: "contains e", Length: 5, Pos: -1},
+		{Question: "pos1 e", Length: 5, Pos: 0},
+		{Question: "pos5 e", Length: 5, Pos: 4},
+		{Question: "pos6 e", Length: 5, Err: ErrAskQuestion},
+		{Question: "pos6 e", Length: 6, Pos: 5},
+		{Question: "pos7 e", Length: 6, Err: ErrAskQuestion},
+		{Question: "pos4 e", Length: 4, Pos: 3},
+		{Question: "pos5 e", Length: 4, Err: ErrAskQuestion},
+		{Question: "pos0 e", Length: 5, Err: ErrAskQuestion},
+		{Question: "pos1 ee", Length: 5, Err: ErrAskQuestion},
+		{Question: "has e", Length: 5, Err: ErrAskQuestion},
+	} {
+		t.Run(tc.Question, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := ParseAskQuestion(tc.Question, tc.Length)
+			if tc.Err != nil {
+				if !errors.Is(err, tc.Err) {
+					t.Fatalf("expected error %v, got %v", tc.Err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if q.Pos != tc.Pos {
+				t.Fatalf("expected position %d, got %d", tc.Pos, q.Pos)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+var ErrBlockedPick = errors.New("Error listed word is hidden by the blocklist")
+
+type (
+	// Blocklist hides words from display and from random targets. It only
+	// affects what is shown, so blocked words remain valid guesses and
+	// answers, and a nil Blocklist blocks nothing.
+	Blocklist struct {
+		words map[wordle.WordleWord]struct{}
+	}
+)
+
+// ReadBlocklist reads lines of a word followed by its category tags, such as
+//
+//	heck mild
+//
+// blocking the words with any of categories, or every word if categories is
+// empty. Blank lines and lines starting with # are ignored.
+func ReadBlocklist(name string, categories []string) (*Blocklist, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading blocklist %s: %w", name, err)
+	}
+	defer f.Close()
+	b := &Blocklist{
+		words: map[wordle.WordleWord]struct{}{},
+	}
+	scanner := bufio.NewScanner(f)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		w, err := wordle.ParseWord(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid blocklist %s: line %d: word '%s': %w", name, lineno, fields[0], err)
+		}
+		if len(categories) == 0 || slices.ContainsFunc(fields[1:], func(c string) bool {
+			return slices.Contains(categories, c)
+		}) {
+			b.words[w] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Failed reading blocklist %s: %w", name, err)
+	}
+	return b, nil
+}
+
+func (b *Blocklist) Blocked(w wordle.WordleWord) bool {
+	if b == nil {
+		return false
+	}
+	_, ok := b.words[w]
+	return ok
+}
+
+// Display returns the word, or a mask of the same length if it is blocked
+func (b *Blocklist) Display(w wordle.WordleWord) string {
+	if b.Blocked(w) {
+		return strings.Repeat("█", w.Len())
+	}
+	return w.String()
+}
+
+// Filter returns the words which are not blocked
+func (b *Blocklist) Filter(words []wordle.WordleWord) []wordle.WordleWord {
+	if b == nil {
+		return words
+	}
+	return slices.DeleteFunc(slices.Clone(words), b.Blocked)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func writeTestBlocklist(t *testing.T) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "blocklist.txt")
+	data := "# word categories\ncakes mild food\n\nfakes rude\n"
+	if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestReadBlocklist(t *testing.T) {
+	t.Parallel()
+
+	name := writeTestBlocklist(t)
+	words := mustWords(t, "bakes", "cakes", "fakes", "makes")
+
+	for _, tc := range []struct {
+		Name       string
+		Categories []string
+		Blocked    []string
+	}{
+		{Name: "every category", Blocked: []string{"cakes", "fakes"}},
+		{Name: "one category", Categories: []string{"rude"}, Blocked: []string{"fakes"}},
+		{Name: "second tag", Categories: []string{"food"}, Blocked: []string{"cakes"}},
+		{Name: "unknown category", Categories: []string{"other"}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := ReadBlocklist(name, tc.Categories)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var blocked []string
+			for _, v := range words {
+				if b.Blocked(v) {
+					blocked = append(blocked, strings.ToLower(v.String()))
+					if got := b.Display(v); got != "█████" {
+						t.Fatalf("expected %s to be masked, got %s", v, got)
+					}
+				} else if got := b.Display(v); got != v.String() {
+					t.Fatalf("expected %s to be shown, got %s", v, got)
+				}
+			}
+			if !slices.Equal(blocked, tc.Blocked) {
+				t.Fatalf("expected %v blocked, got %v", tc.Blocked, blocked)
+			}
+			if got := b.Filter(words); len(got) != len(words)-len(tc.Blocked) || slices.ContainsFunc(got, b.Blocked) {
+				t.Fatalf("expected the blocked words to be filtered, got %v", got)
+			}
+		})
+	}
+}
+
+func TestBlocklistNeverSampled(t *testing.T) {
+	t.Parallel()
+
+	b, err := ReadBlocklist(writeTestBlocklist(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := b.Filter(mustWords(t, "bakes", "cakes", "fakes", "makes"))
+	for seed := range uint64(100) {
+		r := rand.New(rand.NewPCG(seed, 0))
+		for _, v := range RandomTargets(r, pool, 2) {
+			if b.Blocked(v) {
+				t.Fatalf("seed %d sampled blocked target %s", seed, v)
+			}
+		}
+	}
+}
+
+// TestBlocklistSolve replaces os.Stdout, and so does not run in parallel
+func TestBlocklistSolve(t *testing.T) {
+	b, err := ReadBlocklist(writeTestBlocklist(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := mustWords(t, "bakes", "cakes", "fakes", "makes")
+	guesses := append(mustWords(t, "cflmz"), words...)
+	slices.SortFunc(guesses, wordle.WordleWord.Compare)
+	target := mustWords(t, "cakes")[0]
+	input := NewInputReader(strings.NewReader("p\ns\nbakes\np\ncakes\n"))
+
+	var n int
+	var ok bool
+	out := captureStdout(t, func() {
+		n, ok = SimulateGame(wordle.NewLocalTarget(target), input, wordle.NewUniverse(words), guesses, GameOpts{NoColor: true, Blocklist: b}, nil, nil)
+	})
+
+	// the blocked target is accepted as a guess and solves the game
+	if !ok || n != 2 {
+		t.Fatalf("expected a solve in 2 guesses, got %d %t\n%s", n, ok, out)
+	}
+	for _, v := range []string{"CAKES", "FAKES"} {
+		if strings.Contains(out, v) {
+			t.Fatalf("expected %s to never be displayed, got\n%s", v, out)
+		}
+	}
+	if !strings.Contains(out, "█████") {
+		t.Fatalf("expected blocked words to be masked, got\n%s", out)
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+var (
+	ErrCurriculumLesson = errors.New("Error curriculum word outside of a lesson")
+	ErrCurriculumEmpty  = errors.New("Error curriculum has no lessons")
+)
+
+type (
+	// Curriculum is an ordered list of lessons, each of which is an ordered
+	// list of practice targets
+	Curriculum struct {
+		Hash    string
+		Lessons []Lesson
+	}
+
+	Lesson struct {
+		Name    string
+		Targets []wordle.WordleWord
+	}
+
+	// ProgressFile holds the progress through every curriculum keyed by
+	// curriculum hash, so that an edited curriculum starts over
+	ProgressFile struct {
+		Curricula map[string]*CurriculumProgress `json:"curricula"`
+	}
+
+	CurriculumProgress struct {
+		Lessons map[string]*LessonProgress `json:"lessons"`
+	}
+
+	// LessonProgress records the targets completed in order and the guesses
+	// taken to solve them
+	LessonProgress struct {
+		Completed int `json:"completed"`
+		Guesses   int `json:"guesses"`
+	}
+)
+
+// ParseCurriculum reads lessons from lines of the form
+//
+//	[double letters]
+//	geese
+//	llama
+//
+// where blank lines and lines starting with # are ignored
+func ParseCurriculum(r io.Reader) (*Curriculum, error) {
+	var lessons []Lesson
+	h := sha256.New()
+	scanner := bufio.NewScanner(r)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if name, ok := strings.CutPrefix(line, "["); ok {
+			name, ok = strings.CutSuffix(name, "]")
+			if !ok || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("line %d: invalid lesson %q", lineno, line)
+			}
+			name = strings.TrimSpace(name)
+			if slices.ContainsFunc(lessons, func(l Lesson) bool { return l.Name == name }) {
+				return nil, fmt.Errorf("line %d: duplicate lesson %q", lineno, name)
+			}
+			lessons = append(lessons, Lesson{
+				Name: name,
+			})
+			fmt.Fprintf(h, "[%s]\n", name)
+			continue
+		}
+		if len(lessons) == 0 {
+			return nil, fmt.Errorf("line %d: %w", lineno, ErrCurriculumLesson)
+		}
+		w, err := wordle.ParseWord(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: word '%s': %w", lineno, line, err)
+		}
+		l := &lessons[len(lessons)-1]
+		l.Targets = append(l.Targets, w)
+		fmt.Fprintf(h, "%s\n", w)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lessons) == 0 {
+		return nil, ErrCurriculumEmpty
+	}
+	return &Curriculum{
+		Hash:    hex.EncodeToString(h.Sum(nil)),
+		Lessons: lessons,
+	}, nil
+}
+
+func ReadCurriculum(name string) (*Curriculum, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading curriculum %s: %w", name, err)
+	}
+	defer f.Close()
+	c, err := ParseCurriculum(f)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid curriculum %s: %w", name, err)
+	}
+	return c, nil
+}
+
+// Validate checks that every target is an answer of the loaded length
+func (c *Curriculum) Validate(words []wordle.WordleWord) error {
+	for _, l := range c.Lessons {
+		for _, v := range l.Targets {
+			if _, ok := slices.BinarySearchFunc(words, v, wordle.WordleWord.Compare); !ok {
+				return fmt.Errorf("lesson %s: %w: %s", l.Name, ErrNotAnswer, v)
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultProgressFile returns the progress file in the user config directory
+func DefaultProgressFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "wordlebot-progress.json"
+	}
+	return filepath.Join(dir, "wordlebot", "progress.json")
+}
+
+func ReadProgressFile(name string) (*ProgressFile, error) {
+	p := &ProgressFile{
+		Curricula: map[string]*CurriculumProgress{},
+	}
+	b, err := os.ReadFile(name)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return p, nil
+		}
+		return nil, fmt.Errorf("Failed reading progress file %s: %w", name, err)
+	}
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("Invalid progress file %s: %w", name, err)
+	}
+	if p.Curricula == nil {
+		p.Curricula = map[string]*CurriculumProgress{}
+	}
+	return p, nil
+}
+
+func (p *ProgressFile) Write(name string) error {
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("Failed writing progress file %s: %w", name, err)
+	}
+	if err := os.WriteFile(name, b, 0o644); err != nil {
+		return fmt.Errorf("Failed writing progress file %s: %w", name, err)
+	}
+	return nil
+}
+
+// Lesson returns the progress of a lesson, creating it if absent
+func (p *ProgressFile) Lesson(c *Curriculum, name string) *LessonProgress {
+	cp := p.Curricula[c.Hash]
+	if cp == nil {
+		cp = &CurriculumProgress{
+			Lessons: map[string]*LessonProgress{},
+		}
+		p.Curricula[c.Hash] = cp
+	}
+	lp := cp.Lessons[name]
+	if lp == nil {
+		lp = &LessonProgress{}
+		cp.Lessons[name] = lp
+	}
+	return lp
+}
+
+func (p *LessonProgress) Average() float64 {
+	if p.Completed == 0 {
+		return 0
+	}
+	return float64(p.Guesses) / float64(p.Completed)
+}
+
+// PracticeCurriculum plays the remaining targets of each lesson in order,
+// saving progress after every solved target so that a later session resumes
+// where this one stopped
+func PracticeCurriculum(c *Curriculum, progressFile string, play func(target wordle.WordleWord) (int, bool), botAverage func(targets []wordle.WordleWord) float64) error {
+	progress, err := ReadProgressFile(progressFile)
+	if err != nil {
+		return err
+	}
+	for n, l := range c.Lessons {
+		lp := progress.Lesson(c, l.Name)
+		if lp.Completed >= len(l.Targets) {
+			continue
+		}
+		fmt.Printf("Lesson %d/%d: %s\n", n+1, len(c.Lessons), l.Name)
+		for lp.Completed < len(l.Targets) {
+			fmt.Printf("Target %d/%d\n", lp.Completed+1, len(l.Targets))
+			guesses, ok := play(l.Targets[lp.Completed])
+			if !ok {
+				return nil
+			}
+			lp.Completed++
+			lp.Guesses += guesses
+			if err := progress.Write(progressFile); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("Lesson %s complete: your average %.4f guesses, bot average %.4f guesses\n", l.Name, lp.Average(), botAverage(l.Targets))
+	}
+	fmt.Println("Curriculum complete")
+	return nil
+}
+
+func CurriculumCmd(args []string) error {
+	if len(args) == 0 || args[0] != "status" {
+		return errors.New("Usage: curriculum status [-progress-file <file>] <curriculum>")
+	}
+	fs := flag.NewFlagSet("curriculum status", flag.ExitOnError)
+	var progressFile string
+	fs.StringVar(&progressFile, "progress-file", DefaultProgressFile(), "curriculum progress file")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("Usage: curriculum status [-progress-file <file>] <curriculum>")
+	}
+	c, err := ReadCurriculum(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	progress, err := ReadProgressFile(progressFile)
+	if err != nil {
+		return err
+	}
+	completed, total := 0, 0
+	for _, l := range c.Lessons {
+		lp := progress.Lesson(c, l.Name)
+		done := min(lp.Completed, len(l.Targets))
+		completed += done
+		total += len(l.Targets)
+		fmt.Printf("%s: %d/%d", l.Name, done, len(l.Targets))
+		if lp.Completed > 0 {
+			fmt.Printf(", average %.4f guesses", lp.Average())
+		}
+		fmt.Println()
+	}
+	fmt.Printf("total: %d/%d\n", completed, total)
+	return nil
+}
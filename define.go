@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+var ErrNoDefinition = errors.New("No definition found")
+
+type (
+	Definer struct {
+		index   *DefinitionIndex
+		fetcher *DefinitionFetcher
+	}
+
+	DefinitionIndex struct {
+		file    *os.File
+		offsets map[wordle.WordleWord]int64
+	}
+
+	DefinitionFetcher struct {
+		client      *http.Client
+		urlTemplate string
+	}
+)
+
+func NewDefiner(index *DefinitionIndex, fetcher *DefinitionFetcher) *Definer {
+	return &Definer{
+		index:   index,
+		fetcher: fetcher,
+	}
+}
+
+func (d *Definer) Define(w wordle.WordleWord) (string, error) {
+	if d == nil {
+		return "", ErrNoDefinition
+	}
+	if d.index != nil {
+		def, err := d.index.Lookup(w)
+		if err == nil {
+			return sanitizeDefinition(def), nil
+		}
+		if !errors.Is(err, ErrNoDefinition) {
+			return "", err
+		}
+	}
+	if d.fetcher != nil {
+		def, err := d.fetcher.Lookup(w)
+		if err != nil {
+			return "", err
+		}
+		return sanitizeDefinition(def), nil
+	}
+	return "", ErrNoDefinition
+}
+
+func sanitizeDefinition(s string) string {
+	// definitions come from untrusted sources and must not carry control
+	// sequences to the terminal
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsPrint(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func OpenDefinitionIndex(name string) (*DefinitionIndex, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed opening definitions file %s: %w", name, err)
+	}
+	return &DefinitionIndex{
+		file: f,
+	}, nil
+}
+
+func (d *DefinitionIndex) Close() error {
+	return d.file.Close()
+}
+
+func (d *DefinitionIndex) buildOffsets() error {
+	// only line offsets are retained so that large files are not held in memory
+	offsets := map[wordle.WordleWord]int64{}
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(d.file)
+	var offset int64
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			word, _, ok := strings.Cut(line, "\t")
+			if ok {
+				if w, err := wordle.ParseWord(strings.TrimSpace(word)); err == nil {
+					if _, ok := offsets[w]; !ok {
+						offsets[w] = offset
+					}
+				}
+			}
+			offset += int64(len(line))
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+	}
+	d.offsets = offsets
+	return nil
+}
+
+func (d *DefinitionIndex) Lookup(w wordle.WordleWord) (string, error) {
+	if d.offsets == nil {
+		if err := d.buildOffsets(); err != nil {
+			return "", fmt.Errorf("Failed indexing definitions: %w", err)
+		}
+	}
+	offset, ok := d.offsets[w]
+	if !ok {
+		return "", ErrNoDefinition
+	}
+	reader := bufio.NewReader(io.NewSectionReader(d.file, offset, 1<<20))
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("Failed reading definition: %w", err)
+	}
+	_, def, _ := strings.Cut(line, "\t")
+	def = strings.TrimSpace(def)
+	if def == "" {
+		return "", ErrNoDefinition
+	}
+	return def, nil
+}
+
+const (
+	definitionFetchTimeout = 3 * time.Second
+	maxDefinitionLen       = 4096
+)
+
+func NewDefinitionFetcher(urlTemplate string) *DefinitionFetcher {
+	return &DefinitionFetcher{
+		client: &http.Client{
+			Timeout: definitionFetchTimeout,
+		},
+		urlTemplate: urlTemplate,
+	}
+}
+
+func (f *DefinitionFetcher) Lookup(w wordle.WordleWord) (string, error) {
+	u := strings.ReplaceAll(f.urlTemplate, "{word}", url.PathEscape(strings.ToLower(w.String())))
+	res, err := f.client.Get(u)
+	if err != nil {
+		return "", fmt.Errorf("Failed fetching definition: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return "", ErrNoDefinition
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Failed fetching definition: status %d", res.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxDefinitionLen))
+	if err != nil {
+		return "", fmt.Errorf("Failed reading definition: %w", err)
+	}
+	def, _, _ := strings.Cut(strings.TrimSpace(string(body)), "\n")
+	def = strings.TrimSpace(def)
+	if def == "" {
+		return "", ErrNoDefinition
+	}
+	return def, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func TestDefinitionIndex(t *testing.T) {
+	t.Parallel()
+
+	index, err := OpenDefinitionIndex("testdata/definitions.tsv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		index.Close()
+	})
+	definer := NewDefiner(index, nil)
+
+	for _, tc := range []struct {
+		Word string
+		Def  string
+		Err  error
+	}{
+		{Word: "crane", Def: "a large long-necked wading bird"},
+		{Word: "stool", Def: "a seat without a back or arms"},
+		{Word: "slate", Def: "fine grained rock [31msplit[0m into layers"},
+		{Word: "plate", Err: ErrNoDefinition},
+		{Word: "adieu", Err: ErrNoDefinition},
+	} {
+		t.Run(tc.Word, func(t *testing.T) {
+			w, _ := wordle.ParseWord(tc.Word)
+			def, err := definer.Define(w)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+			if def != tc.Def {
+				t.Fatalf("expected %q, got %q", tc.Def, def)
+			}
+		})
+	}
+}
+
+func TestDefinitionFetcher(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/define/stool":
+			io.WriteString(w, "  a seat without a back\nsecond line is dropped\n")
+		case "/define/plate":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/define/slate":
+			time.Sleep(200 * time.Millisecond)
+			io.WriteString(w, "too late")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	index, err := OpenDefinitionIndex("testdata/definitions.tsv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		index.Close()
+	})
+	fetcher := NewDefinitionFetcher(server.URL + "/define/{word}")
+	fetcher.client.Timeout = 50 * time.Millisecond
+	definer := NewDefiner(index, fetcher)
+
+	for _, tc := range []struct {
+		Word string
+		Def  string
+		Err  error
+		Msg  string
+	}{
+		{Word: "crane", Def: "a large long-necked wading bird"},
+		{Word: "stool", Def: "a seat without a back or arms"},
+		{Word: "adieu", Err: ErrNoDefinition},
+		{Word: "plate", Msg: "status 500"},
+		{Word: "slate", Def: "fine grained rock [31msplit[0m into layers"},
+	} {
+		t.Run(tc.Word, func(t *testing.T) {
+			w, _ := wordle.ParseWord(tc.Word)
+			def, err := definer.Define(w)
+			if tc.Msg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Msg) {
+					t.Fatalf("expected error containing %q, got %v", tc.Msg, err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+			if def != tc.Def {
+				t.Fatalf("expected %q, got %q", tc.Def, def)
+			}
+		})
+	}
+
+	t.Run("online only", func(t *testing.T) {
+		definer := NewDefiner(nil, fetcher)
+		w, _ := wordle.ParseWord("stool")
+		def, err := definer.Define(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if def != "a seat without a back" {
+			t.Fatalf("expected first line of the response, got %q", def)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		definer := NewDefiner(nil, fetcher)
+		w, _ := wordle.ParseWord("slate")
+		if _, err := definer.Define(w); err == nil || !strings.Contains(err.Error(), "Failed fetching definition") {
+			t.Fatalf("expected a fetch failure, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+var (
+	ErrDiagnosticsVersion  = errors.New("Error unsupported diagnostics version")
+	ErrDiagnosticsWordlist = errors.New("Error diagnostics wordlist does not match")
+)
+
+const (
+	diagnosticsVersion    = 2
+	diagnosticsInputLines = 50
+)
+
+type (
+	Diagnostics struct {
+		target  *wordle.WordleWord
+		clues   []wordle.Clue
+		history []DiagnosticsTurn
+		input   []string
+	}
+
+	DiagnosticsTurn struct {
+		Guess    string `json:"guess"`
+		Pattern  string `json:"pattern"`
+		Possible int    `json:"possible"`
+	}
+
+	// DiagnosticsUniverse is the full constraint set of a universe. Clue
+	// filters cannot be serialized, so they are recorded by the clues of the
+	// bundle instead.
+	DiagnosticsUniverse struct {
+		BitMask         []uint32 `json:"bitmask"`
+		SolutionChars   uint32   `json:"solution_chars"`
+		EliminatedChars uint32   `json:"eliminated_chars"`
+		Greens          []uint32 `json:"greens"`
+		MinCounts       []int    `json:"min_counts"`
+		MaxCounts       []int    `json:"max_counts"`
+		Candidates      []int    `json:"candidates"`
+	}
+
+	DiagnosticsBundle struct {
+		Version      int                 `json:"version"`
+		Reason       string              `json:"reason"`
+		WordlistHash string              `json:"wordlist_hash"`
+		Args         []string            `json:"args"`
+		Target       string              `json:"target"`
+		Clues        []string            `json:"clues"`
+		History      []DiagnosticsTurn   `json:"history"`
+		Universe     DiagnosticsUniverse `json:"universe"`
+		Input        []string            `json:"input"`
+	}
+)
+
+func NewDiagnostics(target *wordle.WordleWord, clues []wordle.Clue) *Diagnostics {
+	return &Diagnostics{
+		target: target,
+		clues:  clues,
+	}
+}
+
+func NewDiagnosticsUniverse(u wordle.Universe) DiagnosticsUniverse {
+	lo, hi := u.LetterCounts()
+	minCounts := make([]int, 0, len(lo))
+	maxCounts := make([]int, 0, len(hi))
+	for i := range lo {
+		minCounts = append(minCounts, int(lo[i]))
+		maxCounts = append(maxCounts, int(hi[i]))
+	}
+	return DiagnosticsUniverse{
+		BitMask:         u.BitMask().Slice(u.Len()),
+		SolutionChars:   u.SolutionChars(),
+		EliminatedChars: u.EliminatedChars(),
+		Greens:          u.Greens().Slice(u.Len()),
+		MinCounts:       minCounts,
+		MaxCounts:       maxCounts,
+		Candidates:      u.CandidateIndices(),
+	}
+}
+
+func (d *Diagnostics) RecordInput(line string) {
+	if len(d.input) == diagnosticsInputLines {
+		copy(d.input, d.input[1:])
+		d.input = d.input[:len(d.input)-1]
+	}
+	d.input = append(d.input, line)
+}
+
+func (d *Diagnostics) RecordTurn(guess wordle.WordleWord, pattern wordle.WordlePattern, possible int) {
+	d.history = append(d.history, DiagnosticsTurn{
+		Guess:    guess.String(),
+		Pattern:  pattern.Compact(),
+		Possible: possible,
+	})
+}
+
+// UndoTurn drops the last turn so that the history only holds the guesses
+// which produced the current universe
+func (d *Diagnostics) UndoTurn() {
+	if len(d.history) > 0 {
+		d.history = d.history[:len(d.history)-1]
+	}
+}
+
+func (d *Diagnostics) Write(reason string, universe wordle.Universe) (string, error) {
+	target := ""
+	if d.target != nil {
+		target = d.target.String()
+	}
+	clues := make([]string, 0, len(d.clues))
+	for _, v := range d.clues {
+		clues = append(clues, v.String())
+	}
+	bundle := DiagnosticsBundle{
+		Version:      diagnosticsVersion,
+		Reason:       reason,
+		WordlistHash: wordle.WordsHash(universe.Words()),
+		Args:         os.Args[1:],
+		Target:       target,
+		Clues:        clues,
+		History:      d.history,
+		Universe:     NewDiagnosticsUniverse(universe),
+		Input:        d.input,
+	}
+	f, err := os.CreateTemp("", "wordlebot-diagnostics-*.json")
+	if err != nil {
+		return "", fmt.Errorf("Failed creating diagnostics file: %w", err)
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(bundle); err != nil {
+		return "", fmt.Errorf("Failed writing diagnostics file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+func (d *Diagnostics) Report(reason string, universe wordle.Universe) {
+	name, err := d.Write(reason, universe)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s: diagnostics written to %s, please attach it to a bug report\n", reason, name)
+}
+
+func ReadDiagnosticsBundle(name string) (*DiagnosticsBundle, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading diagnostics file %s: %w", name, err)
+	}
+	var bundle DiagnosticsBundle
+	if err := json.Unmarshal(b, &bundle); err != nil {
+		return nil, fmt.Errorf("Invalid diagnostics file %s: %w", name, err)
+	}
+	if bundle.Version != diagnosticsVersion {
+		return nil, fmt.Errorf("%w: %d", ErrDiagnosticsVersion, bundle.Version)
+	}
+	return &bundle, nil
+}
+
+// Rebuild replays the clues and guess history of the bundle over words, and
+// returns the universe which produced the report
+func (b *DiagnosticsBundle) Rebuild(words []wordle.WordleWord) (wordle.Universe, error) {
+	if h := wordle.WordsHash(words); h != b.WordlistHash {
+		return wordle.Universe{}, fmt.Errorf("%w: wordlist hash %s, expected %s", ErrDiagnosticsWordlist, h, b.WordlistHash)
+	}
+	universe := wordle.NewUniverse(words)
+	for _, v := range b.Clues {
+		clue, err := wordle.ParseClue(v)
+		if err != nil {
+			return wordle.Universe{}, err
+		}
+		universe = clue.Apply(universe)
+	}
+	for n, v := range b.History {
+		pattern, err := wordle.ParsePattern(v.Guess, v.Pattern)
+		if err != nil {
+			return wordle.Universe{}, fmt.Errorf("turn %d: %w", n+1, err)
+		}
+		universe = universe.ApplyPattern(pattern)
+	}
+	return universe, nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+type (
+	panicProvider struct{}
+)
+
+func (p panicProvider) Feedback(guess wordle.WordleWord) (wordle.WordlePattern, error) {
+	panic("synthetic failure")
+}
+
+func readBundleFile(t *testing.T, name string) *DiagnosticsBundle {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every field of the file must belong to the schema
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	var strict DiagnosticsBundle
+	if err := dec.Decode(&strict); err != nil {
+		t.Fatalf("bundle does not match the schema: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"version", "reason", "wordlist_hash", "args", "target", "clues", "history", "universe", "input"} {
+		if _, ok := fields[k]; !ok {
+			t.Fatalf("bundle is missing field %s", k)
+		}
+	}
+	bundle, err := ReadDiagnosticsBundle(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bundle
+}
+
+func TestDiagnosticsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	words, err := wordle.DefaultWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, _ := wordle.ParseWord("eerie")
+	clue, err := wordle.ParseClue("doubleletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	universe := clue.Apply(wordle.NewUniverse(words))
+	diag := NewDiagnostics(&target, []wordle.Clue{clue})
+	var history []wordle.Universe
+	for _, v := range []string{"crane", "stool", "geese"} {
+		guess, _ := wordle.ParseWord(v)
+		history = append(history, universe)
+		universe = universe.ApplyPattern(target.ComputePattern(guess))
+		diag.RecordTurn(guess, target.ComputePattern(guess), universe.Count())
+		diag.RecordInput(v)
+	}
+	// the undone guess must not be replayed
+	universe = history[len(history)-1]
+	diag.UndoTurn()
+
+	name, err := diag.Write("synthetic failure", universe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Remove(name)
+	})
+	bundle := readBundleFile(t, name)
+	if bundle.Reason != "synthetic failure" || bundle.Target != "EERIE" {
+		t.Fatalf("unexpected reason %q or target %q", bundle.Reason, bundle.Target)
+	}
+	if !slices.Equal(bundle.Clues, []string{"doubleletter"}) {
+		t.Fatalf("unexpected clues %v", bundle.Clues)
+	}
+	if len(bundle.History) != 2 || bundle.History[1].Guess != "STOOL" {
+		t.Fatalf("expected the 2 remaining turns, got %v", bundle.History)
+	}
+	if !slices.Equal(bundle.Input, []string{"crane", "stool", "geese"}) {
+		t.Fatalf("unexpected input %v", bundle.Input)
+	}
+
+	rebuilt, err := bundle.Rebuild(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := NewDiagnosticsUniverse(rebuilt); !reflect.DeepEqual(got, bundle.Universe) {
+		t.Fatalf("rebuilt universe %+v does not match %+v", got, bundle.Universe)
+	}
+	if rebuilt.Count() != universe.Count() {
+		t.Fatalf("expected %d candidates, got %d", universe.Count(), rebuilt.Count())
+	}
+
+	if _, err := bundle.Rebuild(words[1:]); err == nil {
+		t.Fatal("expected a rebuild against another wordlist to fail")
+	}
+}
+
+func TestSimulateGamePanicWritesDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+
+	words := mustWords(t, "crane", "stool", "plate")
+	input := NewInputReader(strings.NewReader("crane\n"))
+	func() {
+		defer func() {
+			if r := recover(); r != "synthetic failure" {
+				t.Fatalf("expected the panic to be re-raised, got %v", r)
+			}
+		}()
+		SimulateGame(panicProvider{}, input, wordle.NewUniverse(words), words, GameOpts{}, nil, nil)
+	}()
+
+	matches, err := filepath.Glob(filepath.Join(dir, "wordlebot-diagnostics-*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 diagnostics file, got %v", matches)
+	}
+	bundle := readBundleFile(t, matches[0])
+	if bundle.Reason != "panic: synthetic failure" {
+		t.Fatalf("unexpected reason %q", bundle.Reason)
+	}
+	if !slices.Equal(bundle.Input, []string{"crane"}) {
+		t.Fatalf("unexpected input %v", bundle.Input)
+	}
+	if len(bundle.Universe.Candidates) != len(words) {
+		t.Fatalf("expected every candidate, got %v", bundle.Universe.Candidates)
+	}
+}
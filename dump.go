@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+const (
+	suggestionDumpVersion = 1
+	suggestionPriors      = "uniform"
+)
+
+var ErrDumpVersion = errors.New("Unsupported suggestion dump version")
+
+type (
+	SuggestionDump struct {
+		Version    int               `json:"version"`
+		Strategy   string            `json:"strategy"`
+		Priors     string            `json:"priors"`
+		TopN       int               `json:"top_n"`
+		Pool       []string          `json:"pool"`
+		Candidates []int             `json:"candidates"`
+		Ranked     []DumpScoredGuess `json:"ranked"`
+	}
+
+	DumpScoredGuess struct {
+		Word              string  `json:"word"`
+		Entropy           float64 `json:"entropy"`
+		ExpectedRemaining float64 `json:"expected_remaining"`
+		WorstCase         int     `json:"worst_case"`
+		Candidate         bool    `json:"candidate"`
+	}
+)
+
+func NewSuggestionDump(strategy string, universe wordle.Universe, words []wordle.WordleWord, topN int, ranked []wordle.ScoredGuess) SuggestionDump {
+	dump := SuggestionDump{
+		Version:  suggestionDumpVersion,
+		Strategy: strategy,
+		Priors:   suggestionPriors,
+		TopN:     topN,
+		Pool:     make([]string, 0, len(words)),
+	}
+	// pool entries outside the answer list may still match the feedback, so
+	// candidates are taken from the universe rather than the pool
+	candidates := map[wordle.WordleWord]struct{}{}
+	for _, v := range universe.Candidates() {
+		candidates[v] = struct{}{}
+	}
+	for n, v := range words {
+		dump.Pool = append(dump.Pool, v.String())
+		if _, ok := candidates[v]; ok {
+			dump.Candidates = append(dump.Candidates, n)
+		}
+	}
+	for _, v := range ranked {
+		dump.Ranked = append(dump.Ranked, DumpScoredGuess{
+			Word:              v.Word.String(),
+			Entropy:           v.Entropy,
+			ExpectedRemaining: v.ExpectedRemaining,
+			WorstCase:         v.WorstCase,
+			Candidate:         v.Candidate,
+		})
+	}
+	return dump
+}
+
+func WriteSuggestionDump(name string, dump SuggestionDump) error {
+	b, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed encoding suggestion dump: %w", err)
+	}
+	if err := os.WriteFile(name, b, 0o644); err != nil {
+		return fmt.Errorf("Failed writing suggestion dump: %w", err)
+	}
+	return nil
+}
+
+func ReadSuggestionDump(name string) (SuggestionDump, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return SuggestionDump{}, fmt.Errorf("Failed reading suggestion dump: %w", err)
+	}
+	var dump SuggestionDump
+	if err := json.Unmarshal(b, &dump); err != nil {
+		return SuggestionDump{}, fmt.Errorf("Invalid suggestion dump: %w", err)
+	}
+	if dump.Version != suggestionDumpVersion {
+		return SuggestionDump{}, fmt.Errorf("%w: %d", ErrDumpVersion, dump.Version)
+	}
+	return dump, nil
+}
+
+func (d SuggestionDump) Rerun() ([]wordle.ScoredGuess, error) {
+	if (d.Strategy != "entropy" && d.Strategy != "minimax") || d.Priors != suggestionPriors {
+		return nil, fmt.Errorf("Unsupported strategy %s with priors %s", d.Strategy, d.Priors)
+	}
+	pool := make([]wordle.WordleWord, 0, len(d.Pool))
+	for n, i := range d.Pool {
+		w, err := wordle.ParseWord(i)
+		if err != nil {
+			return nil, fmt.Errorf("pool entry %d %q: %w", n+1, i, err)
+		}
+		pool = append(pool, w)
+	}
+	candidates := make([]wordle.WordleWord, 0, len(d.Candidates))
+	for _, i := range d.Candidates {
+		if i < 0 || i >= len(pool) {
+			return nil, fmt.Errorf("Candidate index %d out of range", i)
+		}
+		candidates = append(candidates, pool[i])
+	}
+	if d.Strategy == "minimax" {
+		return wordle.RankGuessesMinimax(pool, candidates, d.TopN), nil
+	}
+	return wordle.RankGuesses(pool, candidates, d.TopN), nil
+}
+
+const (
+	dumpScoreEpsilon = 1e-9
+)
+
+func SolveCmd(args []string) error {
+	fs := flag.NewFlagSet("solve", flag.ExitOnError)
+	var fromDump string
+	fs.StringVar(&fromDump, "from-dump", "", "rerun scoring from a suggestion dump")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fromDump == "" {
+		return errors.New("Usage: solve --from-dump <file>")
+	}
+	dump, err := ReadSuggestionDump(fromDump)
+	if err != nil {
+		return err
+	}
+	ranked, err := dump.Rerun()
+	if err != nil {
+		return err
+	}
+	mismatches := 0
+	for i := range max(len(ranked), len(dump.Ranked)) {
+		var got, expected string
+		if i < len(ranked) {
+			got = fmt.Sprintf("%s %.6f", ranked[i].Word, ranked[i].Entropy)
+		}
+		if i < len(dump.Ranked) {
+			expected = fmt.Sprintf("%s %.6f", dump.Ranked[i].Word, dump.Ranked[i].Entropy)
+		}
+		if i < len(ranked) && i < len(dump.Ranked) &&
+			ranked[i].Word.String() == dump.Ranked[i].Word &&
+			math.Abs(ranked[i].Entropy-dump.Ranked[i].Entropy) < dumpScoreEpsilon {
+			continue
+		}
+		mismatches++
+		fmt.Printf("rank %d: got %q expected %q\n", i+1, got, expected)
+	}
+	if mismatches > 0 {
+		return fmt.Errorf("Ranking differs from dump at %d ranks", mismatches)
+	}
+	fmt.Printf("Ranking matches dump (%d ranks)\n", len(ranked))
+	return nil
+}
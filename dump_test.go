@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func TestSuggestionDumpRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	words, err := wordle.DefaultWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, _ := wordle.ParseWord("stool")
+	universe := wordle.NewUniverse(words)
+	for _, v := range []string{"crane", "moist"} {
+		guess, _ := wordle.ParseWord(v)
+		universe = universe.ApplyPattern(target.ComputePattern(guess))
+	}
+
+	for _, strategy := range []string{"entropy", "minimax"} {
+		t.Run(strategy, func(t *testing.T) {
+			t.Parallel()
+
+			ranked := SuggestGuesses(strategy, universe, words, 10)
+			dump := NewSuggestionDump(strategy, universe, words, 10, ranked)
+			if len(dump.Candidates) != universe.Count() {
+				t.Fatalf("expected %d candidates, got %d", universe.Count(), len(dump.Candidates))
+			}
+			name := filepath.Join(t.TempDir(), "dump.json")
+			if err := WriteSuggestionDump(name, dump); err != nil {
+				t.Fatal(err)
+			}
+			read, err := ReadSuggestionDump(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(read, dump) {
+				t.Fatalf("dump does not survive a round trip")
+			}
+			rerun, err := read.Rerun()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(rerun, ranked) {
+				t.Fatalf("rerun ranking %v does not match %v", rerun, ranked)
+			}
+			if err := SolveCmd([]string{"-from-dump", name}); err != nil {
+				t.Fatal(err)
+			}
+
+			// a regressed ranking is reported
+			dump.Ranked[0], dump.Ranked[1] = dump.Ranked[1], dump.Ranked[0]
+			if err := WriteSuggestionDump(name, dump); err != nil {
+				t.Fatal(err)
+			}
+			if err := SolveCmd([]string{"-from-dump", name}); err == nil {
+				t.Fatal("expected a mismatched ranking to fail")
+			}
+		})
+	}
+}
+
+func TestReadSuggestionDumpInvalid(t *testing.T) {
+	t.Parallel()
+
+	valid := SuggestionDump{
+		Version:    suggestionDumpVersion,
+		Strategy:   "entropy",
+		Priors:     suggestionPriors,
+		TopN:       1,
+		Pool:       []string{"CRANE", "STOOL"},
+		Candidates: []int{1},
+	}
+	for _, tc := range []struct {
+		Name   string
+		Modify func(d *SuggestionDump)
+		Err    error
+		Rerun  bool
+	}{
+		{Name: "version", Modify: func(d *SuggestionDump) { d.Version = suggestionDumpVersion + 1 }, Err: ErrDumpVersion},
+		{Name: "candidate index", Modify: func(d *SuggestionDump) { d.Candidates = []int{2} }, Rerun: true},
+		{Name: "strategy", Modify: func(d *SuggestionDump) { d.Strategy = "first" }, Rerun: true},
+		{Name: "pool word", Modify: func(d *SuggestionDump) { d.Pool[0] = "CR4NE" }, Rerun: true},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			d := valid
+			d.Pool = append([]string(nil), valid.Pool...)
+			tc.Modify(&d)
+			b, err := json.Marshal(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			name := filepath.Join(t.TempDir(), "dump.json")
+			if err := os.WriteFile(name, b, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			read, err := ReadSuggestionDump(name)
+			if !tc.Rerun {
+				if !errors.Is(err, tc.Err) {
+					t.Fatalf("expected error %v, got %v", tc.Err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := read.Rerun(); err == nil {
+				t.Fatal("expected rerun to fail")
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+
+	"github.com/xorkevin/wordlebot/internal/wordlistcheck"
+)
+
+func main() {
+	log.SetFlags(0)
+
+	var output string
+	flag.StringVar(&output, "o", "wordlist_gen.go", "output file")
+	var pkg string
+	flag.StringVar(&pkg, "pkg", "main", "output package name")
+	var prefix string
+	flag.StringVar(&prefix, "prefix", "embeddedWordlist", "generated identifier prefix")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatalln("Usage: genwordlist [flags] <wordlist.json>")
+	}
+	input := flag.Arg(0)
+	data, err := os.ReadFile(input)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	count, err := wordlistcheck.Validate(data)
+	if err != nil {
+		log.Fatalf("%s: %v\n", input, err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by genwordlist from %s; DO NOT EDIT.\n\n", input)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "const (\n")
+	fmt.Fprintf(&b, "%sCount = %d\n", prefix, count)
+	fmt.Fprintf(&b, "%sChecksum = %q\n", prefix, wordlistcheck.Checksum(data))
+	fmt.Fprintf(&b, ")\n")
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		log.Fatalln(err)
+	}
+}
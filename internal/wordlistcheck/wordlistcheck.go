@@ -0,0 +1,94 @@
+package wordlistcheck
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	WordLen = 5
+)
+
+var (
+	ErrNotArray     = errors.New("Wordlist is not a json array")
+	ErrEntryLen     = errors.New("Error word length")
+	ErrEntryChar    = errors.New("Error word char")
+	ErrTrailingData = errors.New("Trailing data after wordlist")
+	ErrMismatch     = errors.New("Wordlist does not match generated metadata")
+)
+
+// Validate checks that data is a json array of lowercase words of length
+// WordLen and returns the number of entries. Errors name the first invalid
+// entry.
+func Validate(data []byte) (int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrNotArray, err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return 0, ErrNotArray
+	}
+	count := 0
+	prev := ""
+	for dec.More() {
+		var s string
+		if err := dec.Decode(&s); err != nil {
+			return count, entryErr(count, prev, fmt.Errorf("Invalid json at offset %d: %w", dec.InputOffset(), err))
+		}
+		if err := validateWord(s); err != nil {
+			return count, fmt.Errorf("entry %d %q: %w", count+1, s, err)
+		}
+		prev = s
+		count++
+	}
+	if _, err := dec.Token(); err != nil {
+		return count, entryErr(count, prev, fmt.Errorf("Unterminated wordlist: %w", err))
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return count, ErrTrailingData
+	}
+	return count, nil
+}
+
+func entryErr(count int, prev string, err error) error {
+	if count == 0 {
+		return fmt.Errorf("entry 1: %w", err)
+	}
+	return fmt.Errorf("entry %d after %q: %w", count+1, prev, err)
+}
+
+func validateWord(s string) error {
+	if len(s) != WordLen {
+		return ErrEntryLen
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return ErrEntryChar
+		}
+	}
+	return nil
+}
+
+func Checksum(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// Verify checks data against the count and checksum recorded at generate
+// time, reporting the first invalid entry if the data no longer validates
+func Verify(data []byte, count int, checksum string) error {
+	if Checksum(data) == checksum {
+		return nil
+	}
+	n, err := Validate(data)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %d entries, expected %d, rerun go generate", ErrMismatch, n, count)
+}
@@ -0,0 +1,88 @@
+package wordlistcheck
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name  string
+		File  string
+		Data  string
+		Count int
+		Err   error
+		Msg   string
+	}{
+		{Name: "valid", File: "testdata/valid.json", Count: 3},
+		{Name: "truncated", File: "testdata/truncated.json", Count: 2, Msg: `entry 3 after "rebut"`},
+		{Name: "bad length", File: "testdata/badlength.json", Count: 46, Err: ErrEntryLen, Msg: `entry 47 "cluster"`},
+		{Name: "bad char", File: "testdata/badchar.json", Count: 1, Err: ErrEntryChar, Msg: `entry 2 "Rebut"`},
+		{Name: "empty array", Data: "[]", Count: 0},
+		{Name: "object", Data: `{"words":["cigar"]}`, Err: ErrNotArray},
+		{Name: "empty", Data: "", Err: ErrNotArray},
+		{Name: "non string entry", Data: `["cigar",12345]`, Count: 1, Msg: `entry 2 after "cigar"`},
+		{Name: "unterminated", Data: `["cigar"`, Count: 1, Msg: `entry 2 after "cigar"`},
+		{Name: "trailing data", Data: `["cigar"] ["rebut"]`, Count: 1, Err: ErrTrailingData},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			data := []byte(tc.Data)
+			if tc.File != "" {
+				var err error
+				data, err = os.ReadFile(tc.File)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			count, err := Validate(data)
+			if count != tc.Count {
+				t.Errorf("expected count %d, got %d", tc.Count, count)
+			}
+			if tc.Err == nil && tc.Msg == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tc.Err != nil && !errors.Is(err, tc.Err) {
+				t.Errorf("expected error %v, got %v", tc.Err, err)
+			}
+			if !strings.Contains(err.Error(), tc.Msg) {
+				t.Errorf("expected error containing %q, got %v", tc.Msg, err)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	valid, err := os.ReadFile("testdata/valid.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksum := Checksum(valid)
+	if err := Verify(valid, 3, checksum); err != nil {
+		t.Fatalf("expected valid data to verify, got %v", err)
+	}
+	// a valid list which was edited without regenerating the metadata
+	if err := Verify([]byte(`["cigar","rebut"]`), 3, checksum); !errors.Is(err, ErrMismatch) {
+		t.Fatalf("expected %v, got %v", ErrMismatch, err)
+	}
+	corrupt, err := os.ReadFile("testdata/badchar.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(corrupt, 3, checksum); !errors.Is(err, ErrEntryChar) {
+		t.Fatalf("expected the first invalid entry, got %v", err)
+	}
+}
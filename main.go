@@ -2,386 +2,1119 @@ package main
 
 import (
 	"bufio"
-	_ "embed"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
-	"math"
-	"math/bits"
+	"math/rand/v2"
+	"net/http"
 	"os"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+
+	"github.com/xorkevin/wordlebot/wordle"
 )
 
 var (
-	ErrWordLen  = errors.New("Error word length")
-	ErrWordChar = errors.New("Error word char")
+	ErrNotGuess     = errors.New("Error word not in guess list")
+	ErrHardMode     = errors.New("Error guess violates hard mode")
+	ErrListingStale = errors.New("Error no current listing, run p again")
+	ErrListingIndex = errors.New("Error listing index out of range")
+	ErrNoHistory    = errors.New("Error no guesses to undo")
+	ErrNoMatches    = errors.New("Error no words match the entered patterns")
+	ErrLineTooLong  = errors.New("Error line too long")
+	ErrLineChar     = errors.New("Error line char")
 )
 
-//go:embed wordlist.json
-var wordlist []byte
-
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	var strWords []string
-	if err := json.Unmarshal(wordlist, &strWords); err != nil {
-		log.Fatalln(err)
-	}
-
-	words := make([]WordleWord, 0, len(strWords))
-	for _, i := range strWords {
-		w, err := ParseWord(i)
-		if err != nil {
-			log.Fatalln(err)
-		}
-		words = append(words, w)
-	}
-
 	var targetWord string
 	flag.StringVar(&targetWord, "target", "", "target word")
+	var randomTarget bool
+	flag.BoolVar(&randomTarget, "random", false, "play against a random target from the answer list")
+	var seed int64
+	flag.Int64Var(&seed, "seed", 0, "random seed for -random, 0 for a random seed")
+	var targetFuzzy bool
+	flag.BoolVar(&targetFuzzy, "target-fuzzy", false, "accept a single letter correction of -target without prompting")
 	var infoGainTarget string
 	flag.StringVar(&infoGainTarget, "calc-info-gain", "", "calculate information gain for a guess")
+	var definitionsFile string
+	flag.StringVar(&definitionsFile, "definitions", "", "word to definition tsv file")
+	var online bool
+	flag.BoolVar(&online, "online", false, "fall back to fetching definitions online")
+	var defineURL string
+	flag.StringVar(&defineURL, "define-url", "", "definition url template with {word} placeholder")
+	var debugAlloc bool
+	flag.BoolVar(&debugAlloc, "debug-alloc", false, "print allocations for each turn and command")
+	var stripAnnotations string
+	flag.StringVar(&stripAnnotations, "strip-annotations", wordle.DefaultStripAnnotations, "annotation characters stripped from the end of wordlist entries")
+	var nonAlpha string
+	flag.StringVar(&nonAlpha, "nonalpha", "reject", "handling of wordlist entries with non letter characters: reject, strip, or skip")
+	var solve bool
+	flag.BoolVar(&solve, "solve", false, "solve a live puzzle by entering feedback patterns by hand")
+	flag.BoolVar(&solve, "interactive", false, "alias for -solve")
+	var askMode bool
+	flag.BoolVar(&askMode, "ask", false, "interrogate the target with yes/no letter questions")
+	var askBudget int
+	flag.IntVar(&askBudget, "ask-budget", 16, "point budget for -ask mode")
+	var clues stringsFlag
+	flag.Var(&clues, "clue", "starting clue: startswith:<letters>, doubleletter, contains:<letters>, or anagram-of:<letters> (repeatable)")
+	var simulateAll bool
+	flag.BoolVar(&simulateAll, "simulate-all", false, "play every target automatically and print aggregate statistics")
+	flag.BoolVar(&simulateAll, "stats", false, "alias for -simulate-all")
+	var opener string
+	flag.StringVar(&opener, "opener", "", "fixed opening guess for -simulate-all and the -preload second guess book")
+	var targetsFile string
+	flag.StringVar(&targetsFile, "targets-file", "", "newline delimited targets for -simulate-all")
+	var strategyName string
+	flag.StringVar(&strategyName, "strategy", "entropy", "guess strategy: first, entropy, or minimax")
+	var format string
+	flag.StringVar(&format, "format", "text", "output format: text or json")
+	var wordlistFile string
+	flag.StringVar(&wordlistFile, "wordlist", "", "JSON array or newline delimited answer list file, or - for stdin in non interactive modes, defaults to the embedded answer list")
+	flag.StringVar(&wordlistFile, "answers", "", "alias for -wordlist")
+	var topCandidates int
+	flag.IntVar(&topCandidates, "candidates", 0, "number of remaining candidates listed in each -format json turn")
+	var jsonLean bool
+	flag.BoolVar(&jsonLean, "json-lean", false, "omit the audit of the last suggestions from each -format json turn")
+	var guessesFile string
+	flag.StringVar(&guessesFile, "guesses", "", "newline delimited allowed guess file, defaults to the embedded guess list")
+	var quordleOpeners bool
+	flag.BoolVar(&quordleOpeners, "quordle-openers", false, "search for the best fixed opening for multi-board play")
+	var boards int
+	flag.IntVar(&boards, "boards", 4, "number of boards, which plays a multi-board game of random targets if set without -quordle-openers")
+	var targetList string
+	flag.StringVar(&targetList, "targets", "", "comma separated targets of a multi-board game")
+	var guessBudget int
+	flag.IntVar(&guessBudget, "guess-budget", 0, "number of guesses in a multi-board game, 0 for the number of boards plus 5")
+	var openerLen int
+	flag.IntVar(&openerLen, "opener-len", 2, "number of guesses in a fixed opening")
+	var openerWidth int
+	flag.IntVar(&openerWidth, "opener-width", 24, "number of most informative words an opening is drawn from")
+	var feedbackURL string
+	flag.StringVar(&feedbackURL, "feedback-url", "", "fetch feedback from a url template with {guess} placeholder")
+	var adversarial bool
+	flag.BoolVar(&adversarial, "adversarial", false, "play against feedback that keeps the most candidates alive")
+	var serveAddr string
+	flag.StringVar(&serveAddr, "serve", "", "serve the suggestion api and a web page at / on an address such as :8080")
+	var preload bool
+	flag.BoolVar(&preload, "preload", false, "build the pattern table, turn one suggestions, and second guess book when serving")
+	var precompute string
+	flag.StringVar(&precompute, "precompute", "", "pattern table cache file, which is built if missing, stale, or corrupt, loaded by -preload, and checked by the table verify subcommand")
+	var preloadPolicy string
+	flag.StringVar(&preloadPolicy, "preload-policy", PreloadBlock, "block to preload before listening, or warm to listen immediately and report warming on /v1/healthz")
+	var hard bool
+	flag.BoolVar(&hard, "hard", false, "reject guesses that do not use every revealed hint and only suggest legal guesses")
+	var curriculumFile string
+	flag.StringVar(&curriculumFile, "curriculum", "", "practice the lessons of a curriculum file in order")
+	var progressFile string
+	flag.StringVar(&progressFile, "progress-file", DefaultProgressFile(), "curriculum progress file")
+	var blocklistFile string
+	flag.StringVar(&blocklistFile, "blocklist", "", "file of words with category tags which are never displayed or picked as random targets")
+	var blockCategories stringsFlag
+	flag.Var(&blockCategories, "block-category", "blocklist category to apply, defaults to every category (repeatable)")
+	var typoCheck bool
+	flag.BoolVar(&typoCheck, "typo-check", false, "offer corrections for words not in the guess list which are one adjacent key away from a guess")
+	var keyboardFile string
+	flag.StringVar(&keyboardFile, "keyboard-layout", "", "keyboard layout file for -typo-check with one row of keys per line, defaults to QWERTY")
+	var noColor bool
+	flag.BoolVar(&noColor, "no-color", os.Getenv("NO_COLOR") != "", "print the board as letter:mark text and share grids with B, Y, and G, defaults to true if NO_COLOR is set")
+	var shareStyleName string
+	flag.StringVar(&shareStyleName, "share-style", "auto", "share grid squares: emoji, ascii [#] [o] [.] blocks, or auto to detect emoji support from TERM, which -no-color replaces with B, Y, and G")
+	var debug bool
+	flag.BoolVar(&debug, "debug", false, "print the charsets and letter masks after each guess")
+	var verbose bool
+	flag.BoolVar(&verbose, "verbose", false, "print the wordlist load report")
+	var wordLen int
+	flag.IntVar(&wordLen, "len", 0, "word length, defaults to the most common length in the wordlist")
 
 	flag.Parse()
 
-	if infoGainTarget != "" {
-		target, err := ParseWord(infoGainTarget)
-		if err != nil {
+	switch flag.Arg(0) {
+	case "pattern":
+		if err := PatternCmd(flag.Args()[1:]); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	case "solve":
+		if err := SolveCmd(flag.Args()[1:]); err != nil {
 			log.Fatalln(err)
 		}
-		universe := Universe{
-			bitMask: WordleWord{allBits, allBits, allBits, allBits, allBits},
+		return
+	case "tournament":
+		if err := TournamentCmd(flag.Args()[1:]); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	case "curriculum":
+		if err := CurriculumCmd(flag.Args()[1:]); err != nil {
+			log.Fatalln(err)
 		}
-		fmt.Println(CalcExpectedInformationGain(target, universe, words))
 		return
 	}
-	target, err := ParseWord(targetWord)
+
+	interactive := !simulateAll && serveAddr == "" && !quordleOpeners && infoGainTarget == "" && precompute == "" && flag.Arg(0) != "table"
+	if err := CheckStdinWordList(interactive, wordlistFile, guessesFile); err != nil {
+		log.Fatalln(err)
+	}
+	nonAlphaPolicy, err := wordle.ParseNonAlphaPolicy(nonAlpha)
 	if err != nil {
 		log.Fatalln(err)
 	}
-	SimulateGame(target, words)
-}
+	shareStyle, err := wordle.ParseShareStyle(shareStyleName, os.Getenv("TERM"))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if noColor && shareStyleName == "auto" {
+		shareStyle = wordle.ShareLetters
+	}
+	if wordLen < 0 || wordLen > wordle.MaxWordLen {
+		log.Fatalf("-len must be between 1 and %d", wordle.MaxWordLen)
+	}
+	loadOpts := wordle.LoadOpts{
+		StripAnnotations: stripAnnotations,
+		NonAlpha:         nonAlphaPolicy,
+		Len:              wordLen,
+	}
+	var words []wordle.WordleWord
+	var report wordle.LoadReport
+	if wordlistFile != "" {
+		words, report, err = ReadWordList(wordlistFile, loadOpts)
+	} else {
+		words, report, err = wordle.LoadEmbeddedWordList(loadOpts)
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	writeLoadReport(report, "wordlist", verbose)
+	if len(words) == 0 {
+		log.Fatalln("Empty wordlist")
+	}
+	n := words[0].Len()
+	var guesses []wordle.WordleWord
+	if guessesFile != "" {
+		guesses, err = ReadWordFile(guessesFile, n)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	} else if n == wordle.DefaultWordLen {
+		guesses, report, err = wordle.LoadEmbeddedGuessList(loadOpts)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		writeLoadReport(report, "guesslist", verbose)
+		if wordlistFile != "" {
+			// the embedded guess list excludes the embedded answers, which remain
+			// legal guesses when a custom answer list replaces them
+			embedded, _, err := wordle.LoadEmbeddedWordList(loadOpts)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			guesses = wordle.MergeWords(embedded, guesses)
+		}
+	}
+	// every possible answer is also a legal guess
+	guesses = wordle.MergeWords(words, guesses)
 
-const (
-	allBits = 0x3ffffff
-)
+	if flag.Arg(0) == "table" {
+		// a cache is checked against the word lists that -precompute builds it
+		// from
+		if err := TableCmd(flag.Args()[1:], guesses, words); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
 
-func SimulateGame(target WordleWord, words []WordleWord) {
-	universe := Universe{
-		bitMask: WordleWord{allBits, allBits, allBits, allBits, allBits},
+	if infoGainTarget != "" {
+		target, err := wordle.ParseWordLen(infoGainTarget, n)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Println(wordle.CalcExpectedInformationGain(target, wordle.NewUniverse(words)))
+		return
 	}
-	numPossibilities := len(words)
-	reader := bufio.NewReader(os.Stdin)
-	for {
-		fmt.Print("Guess: ")
-		line, err := reader.ReadString('\n')
+	if quordleOpeners {
+		if openerLen < 1 || openerLen > 3 {
+			log.Fatalln("-opener-len must be between 1 and 3")
+		}
+		openings := wordle.RankOpenings(guesses, words, openerLen, openerWidth, boards, 10)
+		if err := WriteOpenings(os.Stdout, openings, boards, format); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+	if solve && targetWord != "" {
+		log.Println("-solve ignores -target")
+	}
+	var target *wordle.WordleWord
+	if targetWord != "" && !solve {
+		t, err := ResolveTarget(targetWord, words, targetFuzzy)
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				return
-			}
-			log.Fatalln("Failed reading input")
+			log.Fatalln(err)
 		}
-		line = strings.TrimSpace(line)
-		if line == "p" {
-			for _, v := range words {
-				if universe.Contains(v) {
-					fmt.Println(v)
-				}
+		target = &t
+	}
+	var keyboard *wordle.KeyboardLayout
+	if typoCheck {
+		keyboard = wordle.QWERTYLayout()
+		if keyboardFile != "" {
+			keyboard, err = ReadKeyboardLayout(keyboardFile)
+			if err != nil {
+				log.Fatalln(err)
 			}
-			continue
 		}
-		guess, err := ParseWord(line)
+	}
+	var blocklist *Blocklist
+	if blocklistFile != "" {
+		blocklist, err = ReadBlocklist(blocklistFile, blockCategories)
 		if err != nil {
-			log.Println(err)
-			continue
+			log.Fatalln(err)
 		}
-		universe, numPossibilities = CondenseUniverse(guess, target, universe, words)
-		fmt.Printf("Pattern %s solution charset %026b eliminated charset %026b\n", target.ComputePattern(guess), universe.solutionChars, universe.eliminatedChars)
-		fmt.Println("universe", universe.bitMask.StringMask())
-		fmt.Println(numPossibilities, "possibilities")
-		if numPossibilities < 2 {
-			for _, v := range words {
-				if universe.Contains(v) {
-					fmt.Println(v)
-					break
-				}
+	}
+	if randomTarget {
+		if targetWord != "" {
+			log.Println("-target overrides -random")
+		} else if !solve {
+			r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+			if seed != 0 {
+				r = rand.New(rand.NewPCG(uint64(seed), 0))
 			}
-			break
+			pool := blocklist.Filter(words)
+			if len(pool) == 0 {
+				log.Fatalln("Every answer is blocked")
+			}
+			t := pool[r.IntN(len(pool))]
+			target = &t
 		}
 	}
-}
 
-func CalcExpectedInformationGain(guess WordleWord, universe Universe, words []WordleWord) float64 {
-	universeSize := 0
-	var avgEndEntropy float64
-	for _, v := range words {
-		if !universe.Contains(v) {
-			continue
+	strategy, err := NewStrategy(strategyName, guesses, hard)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	var openerWord wordle.WordleWord
+	if opener != "" {
+		w, err := wordle.ParseWordLen(opener, n)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if _, ok := slices.BinarySearchFunc(guesses, w, wordle.WordleWord.Compare); !ok {
+			log.Fatalln(ErrNotGuess)
+		}
+		strategy = wordle.NewOpenerStrategy(strategy, w)
+		openerWord = w
+	}
+
+	universe := wordle.NewUniverse(words)
+	parsedClues := make([]wordle.Clue, 0, len(clues))
+	for _, i := range clues {
+		clue, err := wordle.ParseClue(i)
+		if err != nil {
+			log.Fatalln(err)
 		}
-		_, count := CondenseUniverse(guess, v, universe, words)
-		entropy := calcEntropy(count)
-		incrSize := float64(universeSize + 1)
-		avgEndEntropy = avgEndEntropy*(float64(universeSize)/incrSize) + entropy/incrSize
-		universeSize++
+		universe = clue.Apply(universe)
+		parsedClues = append(parsedClues, clue)
 	}
-	if universeSize == 0 {
-		return -1
+	if len(clues) > 0 && format != "json" {
+		fmt.Println(universe.Count(), "possibilities after clues")
 	}
-	// information gain is entropy start state - entropy of end state
-	return calcEntropy(universeSize) - avgEndEntropy
-}
 
-func calcEntropy(count int) float64 {
-	return math.Log2(float64(count))
-}
+	if precompute != "" && serveAddr == "" {
+		if n > wordle.MaxMatrixWordLen {
+			log.Fatalf("-precompute only supports words of up to %d letters", wordle.MaxMatrixWordLen)
+		}
+		start := time.Now()
+		if _, err := LoadPatternMatrix(precompute, guesses, universe.Words()); err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("Pattern table cached in %s in %s\n", precompute, time.Since(start).Round(time.Millisecond))
+		return
+	}
 
-type (
-	Universe struct {
-		bitMask                        WordleWord
-		solutionChars, eliminatedChars uint32
+	if serveAddr != "" {
+		if err := ValidPreloadPolicy(preloadPolicy); err != nil {
+			log.Fatalln(err)
+		}
+		s := NewServer(universe, guesses, openerWord, SuggestionStrategy(strategyName), suggestionPriors, hard)
+		switch {
+		case !preload:
+			s.MarkReady()
+		case preloadPolicy == PreloadBlock:
+			s.Preload(precompute)
+		default:
+			go s.Preload(precompute)
+		}
+		server := &http.Server{
+			Addr:              serveAddr,
+			Handler:           s.Handler(),
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+		log.Println("Listening on", serveAddr)
+		log.Fatalln(server.ListenAndServe())
 	}
-)
 
-func CondenseUniverse(guess, target WordleWord, universe Universe, words []WordleWord) (Universe, int) {
-	pattern := target.ComputePattern(guess)
-	for _, v := range pattern {
-		switch v.kind {
-		case PatternKindB:
-			universe.eliminatedChars |= v.v
-		case PatternKindY, PatternKindG:
-			universe.solutionChars |= v.v
+	if simulateAll {
+		targets := words
+		if targetsFile != "" {
+			targets, err = ReadTargetsFile(targetsFile, words)
+			if err != nil {
+				log.Fatalln(err)
+			}
+		}
+		res := wordle.Simulate(targets, strategy, universe, maxGuesses)
+		if err := WriteSimulationResult(os.Stdout, res, format); err != nil {
+			log.Fatalln(err)
 		}
+		return
 	}
-	universe.bitMask = universe.bitMask.Filter(pattern)
-	count := 0
-	var condensed WordleWord
-	for _, v := range words {
-		if universe.Contains(v) {
-			condensed = condensed.Or(v)
-			count++
+
+	if askMode {
+		if target == nil {
+			log.Fatalln("-ask requires -target")
 		}
+		AskGame(*target, universe, askBudget)
+		return
 	}
-	universe.bitMask = condensed
-	return universe, count
-}
 
-func (u Universe) Contains(v WordleWord) bool {
-	vc := v.CharSet()
-	return u.bitMask.Match(v) && vc&u.solutionChars == u.solutionChars && vc&u.eliminatedChars == 0
+	var defIndex *DefinitionIndex
+	if definitionsFile != "" {
+		defIndex, err = OpenDefinitionIndex(definitionsFile)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		defer defIndex.Close()
+	}
+	var defFetcher *DefinitionFetcher
+	if online {
+		if defineURL == "" {
+			log.Fatalln("-online requires -define-url")
+		}
+		defFetcher = NewDefinitionFetcher(defineURL)
+	}
+	var definer *Definer
+	if defIndex != nil || defFetcher != nil {
+		definer = NewDefiner(defIndex, defFetcher)
+	}
+	var tracker *AllocTracker
+	if debugAlloc {
+		// json reports join the other results on stdout
+		allocOut := io.Writer(os.Stderr)
+		if format == "json" {
+			allocOut = os.Stdout
+		}
+		tracker = NewAllocTracker(allocOut, format)
+	}
+	input := NewInputReader(os.Stdin)
+	gameOpts := GameOpts{
+		Strategy:   SuggestionStrategy(strategyName),
+		Hard:       hard,
+		Format:     format,
+		Candidates: topCandidates,
+		Lean:       jsonLean,
+		NoColor:    noColor,
+		ShareStyle: shareStyle,
+		Debug:      debug,
+		Blocklist:  blocklist,
+		Keyboard:   keyboard,
+		Clues:      parsedClues,
+	}
+	boardsSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "boards" {
+			boardsSet = true
+		}
+	})
+	if targetList != "" || boardsSet {
+		if err := CheckMultiBoardOpts(gameOpts); err != nil {
+			log.Fatalln(err)
+		}
+		var targets []wordle.WordleWord
+		if targetList != "" {
+			targets, err = ParseTargets(targetList, words)
+			if err != nil {
+				log.Fatalln(err)
+			}
+		} else {
+			if boards < 1 {
+				log.Fatalln("-boards must be at least 1")
+			}
+			r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+			if seed != 0 {
+				r = rand.New(rand.NewPCG(uint64(seed), 0))
+			}
+			pool := blocklist.Filter(words)
+			if len(pool) < boards {
+				log.Fatalln("Fewer unblocked answers than boards")
+			}
+			targets = RandomTargets(r, pool, boards)
+		}
+		if guessBudget <= 0 {
+			guessBudget = len(targets) + 5
+		}
+		MultiBoardGame(targets, input, universe, guesses, gameOpts, guessBudget)
+		return
+	}
+	if curriculumFile != "" {
+		c, err := ReadCurriculum(curriculumFile)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if err := c.Validate(words); err != nil {
+			log.Fatalln(err)
+		}
+		play := func(target wordle.WordleWord) (int, bool) {
+			return SimulateGame(wordle.NewLocalTarget(target), input, universe, guesses, gameOpts, definer, tracker)
+		}
+		botAverage := func(targets []wordle.WordleWord) float64 {
+			return wordle.Simulate(targets, strategy, universe, maxGuesses).Average
+		}
+		if err := PracticeCurriculum(c, progressFile, play, botAverage); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+	var provider wordle.FeedbackProvider
+	switch {
+	case target != nil:
+		provider = wordle.NewLocalTarget(*target)
+	case feedbackURL != "":
+		provider = wordle.NewRemoteHTTP(feedbackURL)
+	case adversarial:
+		provider = wordle.NewAdversarial(universe)
+	default:
+		var prompt io.Writer = os.Stdout
+		if format == "json" {
+			// prompts would be interleaved with the json output
+			prompt = io.Discard
+		}
+		provider = wordle.NewInteractiveHuman(prompt, input.ReadLine)
+	}
+	SimulateGame(provider, input, universe, guesses, gameOpts, definer, tracker)
+	if randomTarget && targetWord == "" && target != nil {
+		if format == "json" {
+			json.NewEncoder(os.Stdout).Encode(wordle.RevealResult{
+				Target: blocklist.Display(*target),
+			})
+		} else {
+			fmt.Println("The target was", blocklist.Display(*target))
+		}
+	}
 }
 
 type (
-	WordleWord [5]uint32
-
-	PatternKind byte
-
-	WordlePatternLetter struct {
-		v    uint32
-		kind PatternKind
+	// InputReader reads validated lines of user input, and shares them with
+	// an optional recorder
+	InputReader struct {
+		reader *bufio.Reader
+		record func(line string)
 	}
-
-	WordlePattern [5]WordlePatternLetter
-)
-
-const (
-	PatternKindB PatternKind = iota
-	PatternKindY
-	PatternKindG
 )
 
-func (w WordleWord) String() string {
-	var b strings.Builder
-	for _, v := range w {
-		b.WriteByte(byte(bits.TrailingZeros32(v)) + 'A')
+func NewInputReader(r io.Reader) *InputReader {
+	return &InputReader{
+		reader: bufio.NewReader(r),
 	}
-	return b.String()
 }
 
-func (w WordleWord) StringMask() string {
-	return fmt.Sprintf("%026b,%026b,%026b,%026b,%026b", w[0], w[1], w[2], w[3], w[4])
-}
-
-func (w WordleWord) Or(other WordleWord) WordleWord {
-	return WordleWord{
-		w[0] | other[0],
-		w[1] | other[1],
-		w[2] | other[2],
-		w[3] | other[3],
-		w[4] | other[4],
+func (r *InputReader) ReadLine() (string, error) {
+	line, err := ReadLine(r.reader)
+	if err != nil {
+		return "", err
 	}
-}
-
-func (w WordleWord) And(other WordleWord) WordleWord {
-	return WordleWord{
-		w[0] & other[0],
-		w[1] & other[1],
-		w[2] & other[2],
-		w[3] & other[3],
-		w[4] & other[4],
+	line = strings.TrimSpace(line)
+	if r.record != nil {
+		r.record(line)
 	}
+	return line, nil
 }
 
-func (w WordleWord) Match(other WordleWord) bool {
-	return w.And(other) == other
-}
+const (
+	defaultListingLen = 20
+)
 
-func (w WordleWord) CharSet() uint32 {
-	return w[0] | w[1] | w[2] | w[3] | w[4]
-}
+type (
+	GameOpts struct {
+		Strategy   string
+		Hard       bool
+		Format     string
+		Candidates int
+		// Lean omits the suggestion audit from json turns
+		Lean       bool
+		NoColor    bool
+		ShareStyle wordle.ShareStyle
+		Debug      bool
+		Blocklist  *Blocklist
+		// Keyboard enables typo correction if it is not nil
+		Keyboard *wordle.KeyboardLayout
+		// Clues are the starting clues already applied to the universe
+		Clues []wordle.Clue
+	}
 
-func (w WordleWord) Filter(pattern WordlePattern) WordleWord {
-	for i, v := range pattern {
-		switch v.kind {
-		case PatternKindB:
-			var mask uint32 = ^v.v
-			w = w.And(WordleWord{mask, mask, mask, mask, mask})
-		case PatternKindY:
-			var mask uint32 = ^v.v
-			w[i] &= mask
-		case PatternKindG:
-			var mask uint32 = v.v
-			w[i] = mask
-		}
-	}
-	return w
-}
+	gameTurn struct {
+		universe wordle.Universe
+		guess    wordle.WordleWord
+		pattern  wordle.WordlePattern
+		audit    *wordle.SuggestionAudit
+	}
+)
 
-func (w WordleWord) ComputePattern(other WordleWord) WordlePattern {
-	var fullset uint32
-	for _, v := range w {
-		fullset |= v
+// SimulateGame plays a game until one possibility remains or maxGuesses
+// guesses have been made, and returns the number of guesses needed to solve
+// it, which exceeds maxGuesses for a lost game, and whether it finished before
+// the input ended
+func SimulateGame(provider wordle.FeedbackProvider, input *InputReader, universe wordle.Universe, guesses []wordle.WordleWord, opts GameOpts, definer *Definer, tracker *AllocTracker) (int, bool) {
+	// only a local target can be checked against the universe
+	var target *wordle.WordleWord
+	if p, ok := provider.(*wordle.LocalTarget); ok {
+		target = &p.Target
+	}
+	// an adversarial host never reveals its answer, so its game only ends once
+	// the last candidate is guessed
+	_, untilSolved := provider.(*wordle.Adversarial)
+	_, interactive := provider.(*wordle.InteractiveHuman)
+	diag := NewDiagnostics(target, opts.Clues)
+	enc := json.NewEncoder(os.Stdout)
+	logErr := func(err error) {
+		if opts.Format == "json" {
+			enc.Encode(wordle.NewErrorResult(err))
+			return
+		}
+		log.Println(err)
 	}
-	var pattern WordlePattern
-	for i, v := range w {
-		c := other[i]
-		if c == v {
-			pattern[i] = WordlePatternLetter{
-				v:    c,
-				kind: PatternKindG,
+	input.record = diag.RecordInput
+	defer func() {
+		if r := recover(); r != nil {
+			diag.Report(fmt.Sprint("panic: ", r), universe)
+			panic(r)
+		}
+	}()
+	// listing is the most recent p output, which is invalidated by any change
+	// to the universe
+	var listing []wordle.WordleWord
+	// audit is the most recent s output, which is also invalidated by any
+	// change to the universe
+	var audit *wordle.SuggestionAudit
+	// history holds the universe before each applied guess so that guesses
+	// can be undone
+	var history []gameTurn
+	for {
+		if opts.Format != "json" {
+			fmt.Print("Guess: ")
+		}
+		line, err := input.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, false
 			}
-		} else if (c & fullset) != 0 {
-			pattern[i] = WordlePatternLetter{
-				v:    c,
-				kind: PatternKindY,
+			if errors.Is(err, ErrLineTooLong) || errors.Is(err, ErrLineChar) {
+				logErr(err)
+				continue
 			}
-		} else {
-			pattern[i] = WordlePatternLetter{
-				v:    c,
-				kind: PatternKindB,
+			log.Fatalln("Failed reading input")
+		}
+		if line == "u" {
+			if len(history) == 0 {
+				logErr(ErrNoHistory)
+				continue
+			}
+			last := history[len(history)-1]
+			history = history[:len(history)-1]
+			universe = last.universe
+			listing = nil
+			audit = nil
+			diag.UndoTurn()
+			if p, ok := provider.(interface{ Undo() }); ok {
+				p.Undo()
 			}
+			if opts.Format == "json" {
+				enc.Encode(wordle.UndoResult{
+					Undo:      last.guess.String(),
+					Remaining: universe.Count(),
+				})
+				continue
+			}
+			fmt.Printf("Undid %s, %d possibilities\n", last.guess, universe.Count())
+			continue
 		}
-	}
-	return pattern
-}
-
-func (p WordlePattern) String() string {
-	var b strings.Builder
-	for i, v := range p {
-		if i != 0 {
-			b.WriteByte(' ')
+		if line == "h" {
+			if opts.Format == "json" {
+				res := wordle.HistoryResult{
+					History: make([]wordle.TurnResult, 0, len(history)),
+				}
+				for n, v := range history {
+					// each turn remains in the universe before the next turn
+					after := universe
+					if n+1 < len(history) {
+						after = history[n+1].universe
+					}
+					turn := wordle.NewTurnResult(v.guess, v.pattern, after, 0)
+					if !opts.Lean {
+						turn.Audit = v.audit
+					}
+					res.History = append(res.History, turn)
+				}
+				enc.Encode(res)
+				continue
+			}
+			for n, v := range history {
+				fmt.Printf("%d %s %s\n", n+1, v.guess, v.pattern.Compact())
+			}
+			continue
+		}
+		if line == "p" || strings.HasPrefix(line, "p ") {
+			limit := defaultListingLen
+			if arg := strings.TrimSpace(line[1:]); arg == "all" {
+				limit = -1
+			} else if arg != "" {
+				n, err := strconv.Atoi(arg)
+				if err != nil || n < 1 {
+					logErr(errors.New("Usage: p [all|<count>]"))
+					continue
+				}
+				limit = n
+			}
+			tracker.Begin("p")
+			candidates := universe.Candidates()
+			ranked := wordle.RankCandidates(candidates, universe)
+			omitted := 0
+			if limit >= 0 && len(ranked) > limit {
+				omitted = len(ranked) - limit
+				ranked = ranked[:limit]
+			}
+			listing = make([]wordle.WordleWord, 0, len(ranked))
+			res := wordle.ListingResult{
+				Candidates: make([]wordle.ListedWord, 0, len(ranked)),
+				Omitted:    omitted,
+			}
+			for _, v := range ranked {
+				listing = append(listing, v.Word)
+				if opts.Blocklist.Blocked(v.Word) {
+					res.Hidden++
+				}
+				res.Candidates = append(res.Candidates, wordle.ListedWord{
+					Word:  opts.Blocklist.Display(v.Word),
+					Score: v.Score,
+				})
+			}
+			tracker.End(wordsSize(candidates), candidatesSize(universe))
+			if opts.Format == "json" {
+				enc.Encode(res)
+				continue
+			}
+			for n, v := range res.Candidates {
+				fmt.Printf("%d %s %.3f\n", n+1, v.Word, v.Score)
+			}
+			if hidden := res.Hidden; hidden > 0 {
+				fmt.Printf("%d hidden by the blocklist\n", hidden)
+			}
+			if omitted > 0 {
+				fmt.Printf("%d more omitted, run p all to list every candidate\n", omitted)
+			}
+			continue
 		}
-		b.WriteByte(byte(bits.TrailingZeros32(v.v)) + 'A')
-		b.WriteByte(':')
-		switch v.kind {
-		case PatternKindB:
-			b.WriteByte('B')
-		case PatternKindY:
-			b.WriteByte('Y')
-		case PatternKindG:
-			b.WriteByte('G')
+		if idx, ok := strings.CutPrefix(line, "!"); ok {
+			w, err := pickListed(listing, idx)
+			if err != nil {
+				logErr(err)
+				continue
+			}
+			if opts.Blocklist.Blocked(w) {
+				logErr(ErrBlockedPick)
+				continue
+			}
+			line = w.String()
+		}
+		if line == "s" || strings.HasPrefix(line, "s ") {
+			var dumpFile string
+			if args := strings.Fields(line)[1:]; len(args) != 0 {
+				if len(args) != 2 || args[0] != "--dump" {
+					logErr(errors.New("Usage: s [--dump <file>]"))
+					continue
+				}
+				dumpFile = args[1]
+			}
+			tracker.Begin("s")
+			pool := guesses
+			if opts.Hard {
+				pool = universe.HardModeGuesses(guesses)
+			}
+			suggestions := SuggestGuesses(opts.Strategy, universe, pool, 10)
+			tracker.End(wordsSize(pool), candidatesSize(universe), patternsSize(universe.Len(), runtime.GOMAXPROCS(0)))
+			res := wordle.SuggestionsResult{
+				Suggestions: make([]wordle.SuggestionResult, 0, len(suggestions)),
+				Dump:        dumpFile,
+			}
+			for _, v := range suggestions {
+				if opts.Blocklist.Blocked(v.Word) {
+					res.Hidden++
+				}
+				res.Suggestions = append(res.Suggestions, wordle.SuggestionResult{
+					Word:              opts.Blocklist.Display(v.Word),
+					Entropy:           v.Entropy,
+					ExpectedRemaining: v.ExpectedRemaining,
+					WorstCase:         v.WorstCase,
+				})
+			}
+			audit = wordle.NewSuggestionAudit(opts.Strategy, res.Suggestions)
+			if dumpFile != "" {
+				if err := WriteSuggestionDump(dumpFile, NewSuggestionDump(opts.Strategy, universe, pool, 10, suggestions)); err != nil {
+					logErr(err)
+					continue
+				}
+			}
+			if opts.Format == "json" {
+				enc.Encode(res)
+				continue
+			}
+			for n, v := range res.Suggestions {
+				if opts.Strategy == "minimax" {
+					fmt.Printf("%d %s %d worst case remaining %.3f bits\n", n+1, v.Word, v.WorstCase, v.Entropy)
+				} else {
+					fmt.Printf("%d %s %.3f bits %.1f expected remaining\n", n+1, v.Word, v.Entropy, v.ExpectedRemaining)
+				}
+			}
+			if res.Hidden > 0 {
+				fmt.Printf("%d hidden by the blocklist\n", res.Hidden)
+			}
+			if dumpFile != "" {
+				fmt.Println("Wrote suggestion dump to", dumpFile)
+			}
+			continue
+		}
+		if arg, ok := strings.CutPrefix(line, "define "); ok {
+			w, err := wordle.ParseWord(strings.TrimSpace(arg))
+			if err != nil {
+				logErr(err)
+				continue
+			}
+			tracker.Begin("define")
+			def, err := definer.Define(w)
+			tracker.End()
+			if err != nil {
+				logErr(err)
+				continue
+			}
+			if opts.Format == "json" {
+				enc.Encode(wordle.DefinitionResult{
+					Word:       w.String(),
+					Definition: def,
+				})
+				continue
+			}
+			fmt.Printf("%s: %s\n", w, def)
+			continue
+		}
+		if args, ok := strings.CutPrefix(line, "probe-build"); ok {
+			letters, err := wordle.ParseCharSet(args)
+			if err != nil {
+				logErr(err)
+				continue
+			}
+			tracker.Begin("probe-build")
+			probes := wordle.BuildProbes(letters, universe, guesses, 10)
+			tracker.End(wordsSize(guesses), candidatesSize(universe), patternsSize(universe.Len(), 1))
+			res := wordle.ProbesResult{
+				Probes: make([]wordle.ProbeResult, 0, len(probes)),
+			}
+			for _, v := range probes {
+				res.Probes = append(res.Probes, wordle.ProbeResult{
+					Word:    opts.Blocklist.Display(v.Word),
+					Covered: wordle.CharSetString(v.Covered),
+					Missing: wordle.CharSetString(letters &^ v.Covered),
+					Entropy: v.Entropy,
+				})
+			}
+			if opts.Format == "json" {
+				enc.Encode(res)
+				continue
+			}
+			for _, v := range res.Probes {
+				fmt.Printf("%s covers %s missing %s %.3f bits\n", v.Word, v.Covered, v.Missing, v.Entropy)
+			}
+			continue
+		}
+		guess, err := wordle.ParseWordLen(line, universe.Len())
+		if err != nil {
+			logErr(err)
+			continue
+		}
+		if opts.Keyboard != nil && opts.Format != "json" {
+			guess, err = confirmTypo(guess, guesses, opts.Keyboard, opts.Blocklist, input)
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return 0, false
+				}
+				logErr(err)
+				continue
+			}
+		}
+		if _, ok := slices.BinarySearchFunc(guesses, guess, wordle.WordleWord.Compare); !ok {
+			logErr(ErrNotGuess)
+			continue
+		}
+		if opts.Hard {
+			if ok, reason := universe.IsLegalHardModeGuess(guess); !ok {
+				logErr(fmt.Errorf("%w: %s", ErrHardMode, reason))
+				continue
+			}
+		}
+		pattern, err := provider.Feedback(guess)
+		// a mistyped pattern, or one that contradicts the earlier patterns, is
+		// asked for again rather than discarding the guess that was already
+		// entered
+		for interactive {
+			if err == nil && universe.ApplyPattern(pattern).Count() == 0 {
+				err = ErrNoMatches
+			}
+			if !errors.Is(err, wordle.ErrPatternLen) && !errors.Is(err, wordle.ErrPatternChar) && !errors.Is(err, ErrLineTooLong) && !errors.Is(err, ErrLineChar) && !errors.Is(err, ErrNoMatches) {
+				break
+			}
+			logErr(err)
+			pattern, err = provider.Feedback(guess)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, false
+			}
+			logErr(err)
+			continue
+		}
+		history = append(history, gameTurn{
+			universe: universe,
+			guess:    guess,
+			pattern:  pattern,
+			audit:    audit,
+		})
+		tracker.Begin("guess")
+		universe = universe.ApplyPattern(pattern)
+		tracker.End(wordsSize(universe.Words()), candidatesSize(universe))
+		listing = nil
+		audit = nil
+		numPossibilities := universe.Count()
+		diag.RecordTurn(guess, pattern, numPossibilities)
+		if target != nil && !universe.Contains(*target) {
+			diag.Report("constraint bug: target eliminated", universe)
+			return 0, false
+		}
+		if opts.Format == "json" {
+			res := wordle.NewTurnResult(guess, pattern, universe, opts.Candidates)
+			for i, v := range res.Candidates {
+				if w, err := wordle.ParseWord(v); err == nil {
+					res.Candidates[i] = opts.Blocklist.Display(w)
+				}
+			}
+			if !opts.Lean {
+				res.Audit = history[len(history)-1].audit
+			}
+			enc.Encode(res)
+			if numPossibilities == 1 && (!untilSolved || pattern.Solved()) || len(history) == maxGuesses {
+				break
+			}
+			continue
+		}
+		if numPossibilities == 0 {
+			// a remote host may use other word lists, so the turn still counts
+			fmt.Println("No words match the patterns, enter u to undo")
+			if len(history) == maxGuesses {
+				break
+			}
+			continue
+		}
+		for _, v := range history {
+			fmt.Println(v.pattern.Render(!opts.NoColor))
+		}
+		if opts.Debug {
+			fmt.Printf("Pattern %s solution charset %026b eliminated charset %026b\n", pattern, universe.SolutionChars(), universe.EliminatedChars())
+			fmt.Println("universe", universe.BitMask().StringMask())
+		}
+		fmt.Println(numPossibilities, "possibilities")
+		if numPossibilities == 1 && (!untilSolved || pattern.Solved()) {
+			v := universe.Candidates()[0]
+			if opts.Blocklist.Blocked(v) {
+				fmt.Println(opts.Blocklist.Display(v), "hidden by the blocklist")
+			} else if def, err := definer.Define(v); err == nil {
+				fmt.Printf("%s: %s\n", v, def)
+			} else {
+				fmt.Println(v)
+			}
+			break
+		}
+		if len(history) == maxGuesses {
+			break
 		}
 	}
-	return b.String()
-}
-
-func ParseWord(s string) (WordleWord, error) {
-	if len(s) != 5 {
-		return WordleWord{}, ErrWordLen
+	patterns := make([]wordle.WordlePattern, 0, len(history)+1)
+	for _, v := range history {
+		patterns = append(patterns, v.pattern)
 	}
-	s = strings.ToUpper(s)
-	var w WordleWord
-	for i := range w {
-		c := s[i] - 'A'
-		if c > 'Z' {
-			return w, ErrWordChar
+	won := false
+	if universe.Count() == 1 {
+		// the last possibility still needs to be guessed unless it already was
+		if v := universe.Candidates()[0]; history[len(history)-1].guess != v {
+			patterns = append(patterns, v.ComputePattern(v))
 		}
-		w[i] = 1 << c
+		won = len(patterns) <= maxGuesses
+	}
+	if opts.Format != "json" {
+		fmt.Println()
+		fmt.Println(wordle.ShareGrid(patterns[:min(len(patterns), maxGuesses)], maxGuesses, won, opts.ShareStyle))
 	}
-	return w, nil
+	if !won {
+		return maxGuesses + 1, true
+	}
+	return len(patterns), true
 }
 
 type (
-	BitSet struct {
-		bits []uint64
-		size int
-	}
+	stringsFlag []string
 )
 
-func NewBitSet(size int) *BitSet {
-	return &BitSet{
-		bits: make([]uint64, (size+63)/64),
-		size: 0,
-	}
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
 }
 
-func (s *BitSet) Reset() {
-	for i := range s.bits {
-		s.bits[i] = 0
+func pickListed(listing []wordle.WordleWord, idx string) (wordle.WordleWord, error) {
+	if listing == nil {
+		return wordle.WordleWord{}, ErrListingStale
 	}
-	s.size = 0
+	n, err := strconv.Atoi(strings.TrimSpace(idx))
+	if err != nil || n < 1 || n > len(listing) {
+		return wordle.WordleWord{}, fmt.Errorf("%w: expected 1 to %d", ErrListingIndex, len(listing))
+	}
+	return listing[n-1], nil
 }
 
-func (s *BitSet) Size() int {
-	return s.size
+func PatternCmd(args []string) error {
+	fs := flag.NewFlagSet("pattern", flag.ExitOnError)
+	var fromStdin bool
+	fs.BoolVar(&fromStdin, "stdin", false, "read guess target pairs from stdin and write csv")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if !fromStdin {
+		if fs.NArg() != 2 {
+			return errors.New("Usage: pattern <guess> <target>")
+		}
+		return writePattern(os.Stdout, fs.Arg(0), fs.Arg(1))
+	}
+	return writePatternCSV(os.Stdout, os.Stdin)
 }
 
-func (s *BitSet) Contains(i int) bool {
-	a := i / 64
-	mask := uint64(1) << (i % 64)
-	return (s.bits[a] & mask) != 0
+// writePattern writes the colored, compact, and base-3 forms of the pattern
+// of a single guess against a target
+func writePattern(w io.Writer, guessStr, targetStr string) error {
+	guess, err := wordle.ParseWord(guessStr)
+	if err != nil {
+		return err
+	}
+	target, err := wordle.ParseWordLen(targetStr, guess.Len())
+	if err != nil {
+		return err
+	}
+	pattern := target.ComputePattern(guess)
+	fmt.Fprintln(w, pattern.Colored())
+	fmt.Fprintln(w, pattern.Compact())
+	fmt.Fprintln(w, pattern.Code())
+	return nil
 }
 
-func (s *BitSet) Set(i int, b bool) bool {
-	a := i / 64
-	mask := uint64(1) << (i % 64)
-	if b {
-		diff := (s.bits[a] & mask) == 0
-		s.bits[a] |= mask
-		if diff {
-			s.size++
+// writePatternCSV reads guess and target pairs separated by commas or spaces
+// a line at a time, and writes the pattern of each as csv
+func writePatternCSV(out io.Writer, in io.Reader) error {
+	reader := bufio.NewReader(in)
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"guess", "target", "pattern", "code"}); err != nil {
+		return err
+	}
+	for lineno := 1; ; lineno++ {
+		line, err := ReadLine(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("line %d: %w", lineno, err)
 		}
-		return diff
-	} else {
-		diff := (s.bits[a] & mask) != 0
-		s.bits[a] &^= mask
-		if diff {
-			s.size--
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return fmt.Errorf("line %d: expected guess and target", lineno)
+		}
+		guess, err := wordle.ParseWord(fields[0])
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineno, err)
+		}
+		target, err := wordle.ParseWordLen(fields[1], guess.Len())
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineno, err)
+		}
+		pattern := target.ComputePattern(guess)
+		if err := w.Write([]string{guess.String(), target.String(), pattern.Compact(), strconv.Itoa(pattern.Code())}); err != nil {
+			return err
 		}
-		return diff
 	}
+	w.Flush()
+	return w.Error()
 }
 
-func (s *BitSet) Insert(i int) bool {
-	a := i / 64
-	mask := uint64(1) << (i % 64)
-	diff := (s.bits[a] & mask) == 0
-	s.bits[a] |= mask
-	if diff {
-		s.size++
+const (
+	maxLineLen = 256
+)
+
+func ReadLine(reader *bufio.Reader) (string, error) {
+	// overlong lines are consumed and discarded rather than buffered
+	var line []byte
+	tooLong := false
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		if !tooLong {
+			if len(line)+len(chunk) > maxLineLen {
+				tooLong = true
+				line = nil
+			} else {
+				line = append(line, chunk...)
+			}
+		}
+		if !isPrefix {
+			break
+		}
+	}
+	if tooLong {
+		return "", ErrLineTooLong
+	}
+	// reject non-printable bytes so that they are never echoed back
+	for _, c := range line {
+		if c != '\t' && (c < ' ' || c > '~') {
+			return "", ErrLineChar
+		}
 	}
-	return diff
+	return string(line), nil
 }
 
-func (s *BitSet) Remove(i int) bool {
-	a := i / 64
-	mask := uint64(1) << (i % 64)
-	diff := (s.bits[a] & mask) != 0
-	s.bits[a] &^= mask
-	if diff {
-		s.size--
+func writeLoadReport(report wordle.LoadReport, name string, verbose bool) {
+	if verbose {
+		report.Write(os.Stderr, name)
+	} else if len(report.Rejected) > 0 {
+		log.Printf("%s: %d entries rejected, rerun with -verbose for details\n", name, len(report.Rejected))
 	}
-	return diff
 }
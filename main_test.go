@@ -0,0 +1,443 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func mustWords(t testing.TB, words ...string) []wordle.WordleWord {
+	t.Helper()
+	k := make([]wordle.WordleWord, 0, len(words))
+	for _, v := range words {
+		w, err := wordle.ParseWord(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k = append(k, w)
+	}
+	slices.SortFunc(k, wordle.WordleWord.Compare)
+	return k
+}
+
+// captureStdout returns what f writes to os.Stdout, which it replaces, and so
+// its callers do not run in parallel
+func captureStdout(t testing.TB, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(&out, r)
+		close(done)
+	}()
+	f()
+	os.Stdout = stdout
+	w.Close()
+	<-done
+	r.Close()
+	return out.String()
+}
+
+func TestReadLine(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name  string
+		Input string
+		Lines []string
+		Errs  []error
+	}{
+		{
+			Name:  "plain lines",
+			Input: "crane\n\tstool \n",
+			Lines: []string{"crane", "\tstool "},
+			Errs:  []error{nil, nil},
+		},
+		{
+			Name:  "megabyte line is discarded",
+			Input: strings.Repeat("a", 1<<20) + "\ncrane\n",
+			Lines: []string{"", "crane"},
+			Errs:  []error{ErrLineTooLong, nil},
+		},
+		{
+			Name:  "line at the limit",
+			Input: strings.Repeat("a", maxLineLen) + "\n" + strings.Repeat("a", maxLineLen+1) + "\n",
+			Lines: []string{strings.Repeat("a", maxLineLen), ""},
+			Errs:  []error{nil, ErrLineTooLong},
+		},
+		{
+			Name:  "nul byte",
+			Input: "cr\x00ne\ncrane\n",
+			Lines: []string{"", "crane"},
+			Errs:  []error{ErrLineChar, nil},
+		},
+		{
+			Name:  "escape sequence",
+			Input: "\x1b[2J\x1b]0;owned\x07crane\n",
+			Lines: []string{""},
+			Errs:  []error{ErrLineChar},
+		},
+		{
+			Name:  "non ascii byte",
+			Input: "cr\xc3\xa9ne\n",
+			Lines: []string{""},
+			Errs:  []error{ErrLineChar},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			r := bufio.NewReader(strings.NewReader(tc.Input))
+			for i := range tc.Lines {
+				line, err := ReadLine(r)
+				if !errors.Is(err, tc.Errs[i]) {
+					t.Fatalf("line %d: expected error %v, got %v", i, tc.Errs[i], err)
+				}
+				if line != tc.Lines[i] {
+					t.Fatalf("line %d: expected %q, got %q", i, tc.Lines[i], line)
+				}
+			}
+			if _, err := ReadLine(r); !errors.Is(err, io.EOF) {
+				t.Fatalf("expected EOF, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSimulateGameRejectsUntrustedInput(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "crane", "stool", "plate")
+	target, _ := wordle.ParseWord("crane")
+	input := NewInputReader(strings.NewReader(strings.Join([]string{
+		strings.Repeat("x", 1<<20),
+		"cr\x00ne",
+		"\x1b[31mcrane",
+		"crane",
+	}, "\n") + "\n"))
+	n, ok := SimulateGame(wordle.NewLocalTarget(target), input, wordle.NewUniverse(words), words, GameOpts{NoColor: true}, nil, nil)
+	if !ok || n != 1 {
+		t.Fatalf("expected a solve in 1 guess, got %d %t", n, ok)
+	}
+}
+
+func TestSimulateGameReenterPattern(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "crane", "stool", "plate")
+	input := NewInputReader(strings.NewReader(strings.Join([]string{
+		"plate",
+		"BBXBB",
+		"BBY",
+		"cr\x00ne",
+		// the guess is kept, so only the pattern is entered again
+		"bbgbg",
+	}, "\n") + "\n"))
+	provider := wordle.NewInteractiveHuman(io.Discard, input.ReadLine)
+	n, ok := SimulateGame(provider, input, wordle.NewUniverse(words), words, GameOpts{NoColor: true}, nil, nil)
+	// the one remaining candidate is the second guess
+	if !ok || n != 2 {
+		t.Fatalf("expected the game to end after the first pattern, got %d %t", n, ok)
+	}
+}
+
+func TestSimulateGameReenterContradiction(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "crane", "stool", "plate")
+	input := NewInputReader(strings.NewReader(strings.Join([]string{
+		"plate",
+		// no word lacks every letter of plate
+		"bbbbb",
+		"bbgbg",
+	}, "\n") + "\n"))
+	provider := wordle.NewInteractiveHuman(io.Discard, input.ReadLine)
+	n, ok := SimulateGame(provider, input, wordle.NewUniverse(words), words, GameOpts{NoColor: true}, nil, nil)
+	if !ok || n != 2 {
+		t.Fatalf("expected the contradicting pattern to be entered again, got %d %t", n, ok)
+	}
+}
+
+type (
+	fixedProvider struct {
+		pattern wordle.WordlePattern
+	}
+)
+
+func (p fixedProvider) Feedback(guess wordle.WordleWord) (wordle.WordlePattern, error) {
+	return p.pattern, nil
+}
+
+func TestSimulateGameNoMatchesCounted(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "crane", "stool", "plate")
+	pattern, err := wordle.ParsePattern("plate", "bbbbb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// one more guess than allowed is given, which is never read
+	input := NewInputReader(strings.NewReader(strings.Repeat("plate\n", maxGuesses+1)))
+	n, ok := SimulateGame(fixedProvider{pattern: pattern}, input, wordle.NewUniverse(words), words, GameOpts{NoColor: true}, nil, nil)
+	if !ok || n != maxGuesses+1 {
+		t.Fatalf("expected a loss after %d guesses, got %d %t", maxGuesses, n, ok)
+	}
+}
+
+func TestSimulateGameUndo(t *testing.T) {
+	t.Parallel()
+
+	// each guess only eliminates itself, so the game cannot end early
+	words := mustWords(t, "bakes", "cakes", "fakes", "lakes", "makes")
+	target, _ := wordle.ParseWord("makes")
+	input := NewInputReader(strings.NewReader(strings.Join([]string{
+		"u",
+		"bakes",
+		"cakes",
+		"fakes",
+		"u",
+		"u",
+		"h",
+		"makes",
+	}, "\n") + "\n"))
+	n, ok := SimulateGame(wordle.NewLocalTarget(target), input, wordle.NewUniverse(words), words, GameOpts{NoColor: true}, nil, nil)
+	// the two undone guesses are not counted
+	if !ok || n != 2 {
+		t.Fatalf("expected a solve in 2 guesses, got %d %t", n, ok)
+	}
+}
+
+func TestWritePattern(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Guess, Target string
+		Compact       string
+		Code          string
+		Err           error
+	}{
+		{Guess: "speed", Target: "abide", Compact: "BBYBY", Code: "90"},
+		{Guess: "eerie", Target: "geese", Compact: "YGBBG", Code: "169"},
+		{Guess: "crane", Target: "crane", Compact: "GGGGG", Code: "242"},
+		{Guess: "crane", Target: "cran", Err: wordle.ErrWordLen},
+		{Guess: "cr4ne", Target: "crane", Err: wordle.ErrWordChar},
+	} {
+		t.Run(tc.Guess+" "+tc.Target, func(t *testing.T) {
+			t.Parallel()
+
+			var b strings.Builder
+			err := writePattern(&b, tc.Guess, tc.Target)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+			if err != nil {
+				return
+			}
+			pattern, err := wordle.ParsePattern(tc.Guess, tc.Compact)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := pattern.Colored() + "\n" + tc.Compact + "\n" + tc.Code + "\n"; b.String() != want {
+				t.Fatalf("expected %q, got %q", want, b.String())
+			}
+		})
+	}
+}
+
+func TestWritePatternCSV(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name  string
+		Input string
+		CSV   string
+		Err   string
+	}{
+		{
+			Name:  "pairs",
+			Input: "speed,abide\neerie geese\n\n  crane , crane\n",
+			CSV:   "guess,target,pattern,code\nSPEED,ABIDE,BBYBY,90\nEERIE,GEESE,YGBBG,169\nCRANE,CRANE,GGGGG,242\n",
+		},
+		{Name: "empty", Input: "", CSV: "guess,target,pattern,code\n"},
+		{Name: "extra field", Input: "speed,abide\nspeed,abide,crane\n", Err: "line 2: expected guess and target"},
+		{Name: "missing target", Input: "speed\n", Err: "line 1: expected guess and target"},
+		{Name: "length mismatch", Input: "speed,abid\n", Err: "line 1: " + wordle.ErrWordLen.Error()},
+		{Name: "bad char", Input: "\nsp3ed,abide\n", Err: "line 2: " + wordle.ErrWordChar.Error()},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var b strings.Builder
+			err := writePatternCSV(&b, strings.NewReader(tc.Input))
+			if tc.Err != "" {
+				if err == nil || err.Error() != tc.Err {
+					t.Fatalf("expected error %q, got %v", tc.Err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tc.CSV {
+				t.Fatalf("expected %q, got %q", tc.CSV, b.String())
+			}
+		})
+	}
+}
+
+// TestSimulateGameAudit replaces os.Stdout, and so does not run in parallel
+func TestSimulateGameAudit(t *testing.T) {
+	words := mustWords(t, "bakes", "cakes", "fakes", "lakes", "makes")
+	target := mustWords(t, "makes")[0]
+
+	for _, tc := range []struct {
+		Name   string
+		Lean   bool
+		Audits []bool
+	}{
+		// only the guess after s carries an audit, since the guess
+		// invalidates it, and h repeats the audits of the played turns
+		{Name: "audit", Audits: []bool{true, false, true, false}},
+		{Name: "lean", Lean: true, Audits: []bool{false, false, false, false}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			input := NewInputReader(strings.NewReader("s\nbakes\ncakes\ns\nh\n"))
+			out := captureStdout(t, func() {
+				SimulateGame(wordle.NewLocalTarget(target), input, wordle.NewUniverse(words), words, GameOpts{Strategy: "entropy", Format: "json", Lean: tc.Lean}, nil, nil)
+			})
+			dec := json.NewDecoder(strings.NewReader(out))
+			var turns []wordle.TurnResult
+			for {
+				var v struct {
+					wordle.TurnResult
+					wordle.HistoryResult
+				}
+				if err := dec.Decode(&v); err != nil {
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					t.Fatal(err)
+				}
+				if v.Guess != "" {
+					turns = append(turns, v.TurnResult)
+				}
+				turns = append(turns, v.History...)
+			}
+			if len(turns) != len(tc.Audits) {
+				t.Fatalf("expected %d turns, got %d\n%s", len(tc.Audits), len(turns), out)
+			}
+			for i, v := range turns {
+				if v.Schema != wordle.TurnSchema {
+					t.Fatalf("turn %d: expected schema %d, got %d", i, wordle.TurnSchema, v.Schema)
+				}
+				if want := tc.Audits[i]; (v.Audit != nil) != want {
+					t.Fatalf("turn %d: expected audit %t, got %+v\n%s", i, want, v.Audit, out)
+				}
+				if v.Audit != nil && (v.Audit.Strategy != "entropy" || len(v.Audit.Alternatives) != wordle.AuditLen) {
+					t.Fatalf("turn %d: expected %d entropy alternatives, got %+v", i, wordle.AuditLen, v.Audit)
+				}
+			}
+		})
+	}
+}
+
+// TestSimulateGamePickGolden replaces os.Stdout and the log output, and so
+// does not run in parallel
+func TestSimulateGamePickGolden(t *testing.T) {
+	words := mustWords(t, "bakes", "cakes", "fakes", "lakes", "makes")
+	target := mustWords(t, "makes")[0]
+	input := NewInputReader(strings.NewReader(strings.Join([]string{
+		"!1",
+		"p",
+		"!6",
+		"!0",
+		"!2",
+		"!1",
+		"p",
+		"!4",
+	}, "\n") + "\n"))
+	var n int
+	var ok bool
+	out := captureStdout(t, func() {
+		// errors are logged, and are written in order with the rest of the
+		// transcript
+		log.SetOutput(os.Stdout)
+		log.SetFlags(0)
+		defer func() {
+			log.SetOutput(os.Stderr)
+			log.SetFlags(log.LstdFlags)
+		}()
+		n, ok = SimulateGame(wordle.NewLocalTarget(target), input, wordle.NewUniverse(words), words, GameOpts{NoColor: true}, nil, nil)
+	})
+	// the stale and out of range picks do not use up a guess
+	if !ok || n != 2 {
+		t.Fatalf("expected a solve in 2 guesses, got %d %t\n%s", n, ok, out)
+	}
+	name := filepath.Join("testdata", "pick.golden")
+	if *update {
+		if err := os.WriteFile(name, []byte(out), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != string(golden) {
+		t.Fatalf("expected %s, got %s", golden, out)
+	}
+}
+
+// TestGuessOnlyWord replaces os.Stdout, and so does not run in parallel
+func TestGuessOnlyWord(t *testing.T) {
+	answers := mustWords(t, "bakes", "cakes", "fakes")
+	guessOnly := mustWords(t, "bikes")[0]
+	guesses := wordle.MergeWords(answers, []wordle.WordleWord{guessOnly})
+	target := mustWords(t, "cakes")[0]
+	universe := wordle.NewUniverse(answers)
+
+	input := NewInputReader(strings.NewReader("bikes\np\n"))
+	out := captureStdout(t, func() {
+		SimulateGame(wordle.NewLocalTarget(target), input, universe, guesses, GameOpts{NoColor: true}, nil, nil)
+	})
+	// the guess is accepted and applied, but is never listed as a possibility
+	if !strings.Contains(out, "B:B I:B K:G E:G S:G\n2 possibilities") {
+		t.Fatalf("expected BIKES to be played, got\n%s", out)
+	}
+	if !strings.Contains(out, "CAKES") || strings.Contains(out, "BIKES") {
+		t.Fatalf("expected only answers to be listed, got\n%s", out)
+	}
+
+	after := universe.Apply(guessOnly, target.ComputePattern(guessOnly))
+	for _, u := range []wordle.Universe{universe, after} {
+		if slices.Contains(u.Candidates(), guessOnly) || u.Contains(guessOnly) {
+			t.Fatalf("expected %s to never be a candidate", guessOnly)
+		}
+		// probes from the guess list may be suggested, but never as a possible
+		// answer
+		for _, v := range SuggestGuesses("entropy", u, guesses, len(guesses)) {
+			if v.Word == guessOnly && v.Candidate {
+				t.Fatalf("expected %s to never be suggested as a candidate", guessOnly)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+const (
+	// matrixVerifySamples is the number of patterns recomputed each time a
+	// cache is loaded, which takes about a millisecond for the full lists
+	matrixVerifySamples = 4096
+)
+
+// LoadPatternMatrix reads the pattern matrix cached in name, and rebuilds and
+// rewrites the cache if it is missing, was built from other word lists, or
+// is corrupt
+func LoadPatternMatrix(name string, guesses, answers []wordle.WordleWord) (*wordle.PatternMatrix, error) {
+	f, err := os.Open(name)
+	if err == nil {
+		m, err := wordle.ReadPatternMatrix(bufio.NewReader(f), guesses, answers)
+		f.Close()
+		if err == nil {
+			err = m.VerifySample(rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())), matrixVerifySamples)
+		}
+		if err == nil {
+			return m, nil
+		}
+		log.Printf("Rebuilding pattern matrix cache %s: %v", name, err)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("Failed opening pattern matrix cache %s: %w", name, err)
+	}
+	m := wordle.NewPatternMatrix(guesses, answers)
+	if err := writePatternMatrix(name, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TableCmd checks a pattern matrix cache against the word lists, recomputing
+// every pattern with --full rather than a sample
+func TableCmd(args []string, guesses, answers []wordle.WordleWord) error {
+	usage := errors.New("Usage: table verify [--full] <file>")
+	if len(args) == 0 || args[0] != "verify" {
+		return usage
+	}
+	fset := flag.NewFlagSet("table verify", flag.ExitOnError)
+	var full bool
+	fset.BoolVar(&full, "full", false, "recompute every pattern instead of a sample")
+	if err := fset.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fset.NArg() != 1 {
+		return usage
+	}
+	name := fset.Arg(0)
+	f, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("Failed opening pattern matrix cache %s: %w", name, err)
+	}
+	m, err := wordle.ReadPatternMatrix(bufio.NewReader(f), guesses, answers)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("Invalid pattern matrix cache %s: %w", name, err)
+	}
+	checked := matrixVerifySamples
+	if full {
+		checked = len(guesses) * len(answers)
+		err = m.Verify()
+	} else {
+		err = m.VerifySample(rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())), matrixVerifySamples)
+	}
+	if err != nil {
+		return fmt.Errorf("Invalid pattern matrix cache %s: %w", name, err)
+	}
+	fmt.Printf("Pattern matrix cache %s verified (%d patterns checked)\n", name, checked)
+	return nil
+}
+
+func writePatternMatrix(name string, m *wordle.PatternMatrix) error {
+	// the cache is written to a temporary file and renamed into place so that
+	// an interrupted write never leaves a truncated cache
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("Failed creating pattern matrix cache %s: %w", name, err)
+	}
+	defer os.Remove(f.Name())
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return fmt.Errorf("Failed creating pattern matrix cache %s: %w", name, err)
+	}
+	if _, err := m.WriteTo(f); err != nil {
+		f.Close()
+		return fmt.Errorf("Failed writing pattern matrix cache %s: %w", name, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("Failed writing pattern matrix cache %s: %w", name, err)
+	}
+	if err := os.Rename(f.Name(), name); err != nil {
+		return fmt.Errorf("Failed writing pattern matrix cache %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func TestLoadPatternMatrix(t *testing.T) {
+	t.Parallel()
+
+	answers := mustWords(t, "bakes", "cakes", "makes")
+	guesses := mustWords(t, "bakes", "cakes", "cflmz", "makes")
+	name := filepath.Join(t.TempDir(), "cache.bin")
+
+	for _, tc := range []struct {
+		Name    string
+		Answers []wordle.WordleWord
+		Corrupt bool
+	}{
+		{Name: "missing", Answers: answers},
+		{Name: "cached", Answers: answers},
+		{Name: "stale", Answers: mustWords(t, "bakes", "takes")},
+		{Name: "corrupt", Answers: mustWords(t, "bakes", "takes"), Corrupt: true},
+	} {
+		if tc.Corrupt {
+			corruptLastByte(t, name)
+		}
+		// each case loads the cache left by the one before it
+		m, err := LoadPatternMatrix(name, guesses, tc.Answers)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.Name, err)
+		}
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.Name, err)
+		}
+		var b bytes.Buffer
+		if _, err := wordle.NewPatternMatrix(guesses, tc.Answers).WriteTo(&b); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, b.Bytes()) {
+			t.Fatalf("%s: cache does not match the word lists", tc.Name)
+		}
+		if got, want := m.Pattern(2, 0), wordle.EncodePattern(tc.Answers[0].ComputePattern(guesses[2])); got != want {
+			t.Fatalf("%s: expected pattern %d, got %d", tc.Name, want, got)
+		}
+	}
+}
+
+func corruptLastByte(t *testing.T, name string) {
+	t.Helper()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-1] ^= 1
+	if err := os.WriteFile(name, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTableCmd(t *testing.T) {
+	t.Parallel()
+
+	answers := mustWords(t, "bakes", "cakes", "makes")
+	guesses := mustWords(t, "bakes", "cakes", "cflmz", "makes")
+
+	for _, tc := range []struct {
+		Name    string
+		Args    []string
+		Corrupt bool
+		Err     error
+		Msg     string
+	}{
+		{Name: "sample", Args: []string{"verify"}},
+		{Name: "full", Args: []string{"verify", "--full"}},
+		{Name: "corrupt sample", Args: []string{"verify"}, Corrupt: true, Err: wordle.ErrCorruptMatrix},
+		{Name: "corrupt full", Args: []string{"verify", "--full"}, Corrupt: true, Err: wordle.ErrCorruptMatrix},
+		{Name: "no subcommand", Args: []string{}, Msg: "Usage: table verify"},
+		{Name: "unknown subcommand", Args: []string{"build"}, Msg: "Usage: table verify"},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			name := filepath.Join(t.TempDir(), "cache.bin")
+			if _, err := LoadPatternMatrix(name, guesses, answers); err != nil {
+				t.Fatal(err)
+			}
+			if tc.Corrupt {
+				corruptLastByte(t, name)
+			}
+			args := tc.Args
+			if len(args) > 0 && args[0] == "verify" {
+				args = append(slices.Clone(args), name)
+			}
+			err := TableCmd(args, guesses, answers)
+			if tc.Msg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Msg) {
+					t.Fatalf("expected error containing %q, got %v", tc.Msg, err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+		})
+	}
+}
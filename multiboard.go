@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+var ErrMultiBoardFlag = errors.New("Error flag not supported by multi-board play")
+
+type (
+	board struct {
+		target   wordle.WordleWord
+		universe wordle.Universe
+		solved   bool
+	}
+)
+
+// CheckMultiBoardOpts rejects the game options that a multi-board game does
+// not support, which are json output and hard mode
+func CheckMultiBoardOpts(opts GameOpts) error {
+	if opts.Format == "json" {
+		return fmt.Errorf("%w: -format json", ErrMultiBoardFlag)
+	}
+	if opts.Hard {
+		return fmt.Errorf("%w: -hard", ErrMultiBoardFlag)
+	}
+	return nil
+}
+
+// ParseTargets parses a comma separated list of answers
+func ParseTargets(s string, words []wordle.WordleWord) ([]wordle.WordleWord, error) {
+	var targets []wordle.WordleWord
+	for _, i := range strings.Split(s, ",") {
+		t, err := wordle.ParseWordLen(strings.TrimSpace(i), words[0].Len())
+		if err != nil {
+			return nil, fmt.Errorf("target %q: %w", i, err)
+		}
+		if _, ok := slices.BinarySearchFunc(words, t, wordle.WordleWord.Compare); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotAnswer, t)
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// RandomTargets picks n distinct answers
+func RandomTargets(r *rand.Rand, words []wordle.WordleWord, n int) []wordle.WordleWord {
+	targets := make([]wordle.WordleWord, 0, n)
+	for _, i := range r.Perm(len(words))[:min(n, len(words))] {
+		targets = append(targets, words[i])
+	}
+	return targets
+}
+
+// MultiBoardGame plays every target at once with the same guesses until
+// every board is solved or budget guesses have been made, and returns the
+// number of guesses made and whether every board was solved
+func MultiBoardGame(targets []wordle.WordleWord, input *InputReader, universe wordle.Universe, guesses []wordle.WordleWord, opts GameOpts, budget int) (int, bool) {
+	boards := make([]board, 0, len(targets))
+	for _, v := range targets {
+		boards = append(boards, board{
+			target:   v,
+			universe: universe,
+		})
+	}
+	// a solved board is drawn as blanks of the visible width of a row
+	blank := strings.Repeat(" ", universe.Len()*3)
+	if opts.NoColor {
+		blank = strings.Repeat(" ", universe.Len()*4-1)
+	}
+	turns := 0
+	for turns < budget {
+		fmt.Print("Guess: ")
+		line, err := input.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return turns, false
+			}
+			if errors.Is(err, ErrLineTooLong) || errors.Is(err, ErrLineChar) {
+				log.Println(err)
+				continue
+			}
+			log.Fatalln("Failed reading input")
+		}
+		if line == "s" {
+			var unsolved []wordle.Universe
+			for _, b := range boards {
+				if !b.solved {
+					unsolved = append(unsolved, b.universe)
+				}
+			}
+			for n, v := range wordle.RankGuessesMultiBoard(guesses, unsolved, 10) {
+				fmt.Printf("%d %s %.1f expected remaining %.3f bits\n", n+1, opts.Blocklist.Display(v.Word), v.ExpectedRemaining, v.Entropy)
+			}
+			continue
+		}
+		guess, err := wordle.ParseWordLen(line, universe.Len())
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if _, ok := slices.BinarySearchFunc(guesses, guess, wordle.WordleWord.Compare); !ok {
+			log.Println(ErrNotGuess)
+			continue
+		}
+		turns++
+		rows := make([]string, 0, len(boards))
+		counts := make([]string, 0, len(boards))
+		done := true
+		for i := range boards {
+			b := &boards[i]
+			if b.solved {
+				rows = append(rows, blank)
+				counts = append(counts, fmt.Sprintf("%-*s", len(blank), "done"))
+				continue
+			}
+			pattern := b.target.ComputePattern(guess)
+			b.universe = b.universe.ApplyPattern(pattern)
+			b.solved = pattern.Solved()
+			done = done && b.solved
+			rows = append(rows, pattern.Render(!opts.NoColor))
+			count := fmt.Sprintf("%d left", b.universe.Count())
+			if b.solved {
+				count = "solved"
+			}
+			counts = append(counts, fmt.Sprintf("%-*s", len(blank), count))
+		}
+		fmt.Println(strings.Join(rows, "  "))
+		fmt.Println(strings.TrimRight(strings.Join(counts, "  "), " "))
+		if done {
+			fmt.Printf("Solved %d boards in %d guesses\n", len(boards), turns)
+			return turns, true
+		}
+	}
+	k := make([]string, 0, len(boards))
+	for _, b := range boards {
+		k = append(k, opts.Blocklist.Display(b.target))
+	}
+	fmt.Printf("Out of guesses, the targets were %s\n", strings.Join(k, " "))
+	return turns, false
+}
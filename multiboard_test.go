@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func TestMultiBoardGame(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "fakes", "makes", "takes")
+	guesses := mustWords(t, "bakes", "cakes", "fakes", "makes", "takes", "tbcmz")
+	targets := mustWords(t, "cakes", "makes")
+
+	for _, tc := range []struct {
+		Name   string
+		Input  []string
+		Budget int
+		Turns  int
+		Solved bool
+	}{
+		{
+			// tbcmz separates every answer, after which each board is
+			// solved in one guess
+			Name:   "solved",
+			Input:  []string{"s", "tbcmz", "cakes", "makes"},
+			Budget: 5,
+			Turns:  3,
+			Solved: true,
+		},
+		{
+			Name:   "solved boards are skipped",
+			Input:  []string{"makes", "makes", "cakes"},
+			Budget: 5,
+			Turns:  3,
+			Solved: true,
+		},
+		{
+			Name:   "invalid guesses are not counted",
+			Input:  []string{"lakes", "cake", "cakes", "makes"},
+			Budget: 2,
+			Turns:  2,
+			Solved: true,
+		},
+		{
+			Name:   "out of guesses",
+			Input:  []string{"bakes", "cakes", "makes"},
+			Budget: 2,
+			Turns:  2,
+		},
+		{
+			Name:   "end of input",
+			Input:  []string{"cakes"},
+			Budget: 5,
+			Turns:  1,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			input := NewInputReader(strings.NewReader(strings.Join(tc.Input, "\n") + "\n"))
+			turns, solved := MultiBoardGame(targets, input, wordle.NewUniverse(words), guesses, GameOpts{NoColor: true}, tc.Budget)
+			if turns != tc.Turns || solved != tc.Solved {
+				t.Fatalf("expected %d %t, got %d %t", tc.Turns, tc.Solved, turns, solved)
+			}
+		})
+	}
+}
+
+func TestCheckMultiBoardOpts(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name string
+		Opts GameOpts
+		Msg  string
+	}{
+		{Name: "text", Opts: GameOpts{Format: "text", NoColor: true}},
+		{Name: "json", Opts: GameOpts{Format: "json"}, Msg: "Error flag not supported by multi-board play: -format json"},
+		{Name: "hard", Opts: GameOpts{Format: "text", Hard: true}, Msg: "Error flag not supported by multi-board play: -hard"},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckMultiBoardOpts(tc.Opts)
+			if tc.Msg == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrMultiBoardFlag) || err.Error() != tc.Msg {
+				t.Fatalf("expected error %q, got %v", tc.Msg, err)
+			}
+		})
+	}
+}
+
+func TestParseTargets(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "fakes")
+	for _, tc := range []struct {
+		Targets string
+		Want    []string
+		Err     error
+	}{
+		{Targets: "cakes,bakes", Want: []string{"CAKES", "BAKES"}},
+		{Targets: " cakes , fakes ", Want: []string{"CAKES", "FAKES"}},
+		{Targets: "cakes,lakes", Err: ErrNotAnswer},
+		{Targets: "cakes,cake", Err: wordle.ErrWordLen},
+	} {
+		t.Run(tc.Targets, func(t *testing.T) {
+			t.Parallel()
+
+			targets, err := ParseTargets(tc.Targets, words)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+			if err != nil {
+				return
+			}
+			got := make([]string, 0, len(targets))
+			for _, v := range targets {
+				got = append(got, v.String())
+			}
+			if strings.Join(got, ",") != strings.Join(tc.Want, ",") {
+				t.Fatalf("expected %v, got %v", tc.Want, got)
+			}
+		})
+	}
+}
+
+func TestRandomTargets(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "fakes")
+	for _, n := range []int{1, 2, 3, 4} {
+		targets := RandomTargets(rand.New(rand.NewPCG(1, 0)), words, n)
+		if len(targets) != min(n, len(words)) {
+			t.Fatalf("expected %d targets, got %d", min(n, len(words)), len(targets))
+		}
+		seen := map[wordle.WordleWord]struct{}{}
+		for _, v := range targets {
+			if _, ok := seen[v]; ok {
+				t.Fatalf("expected distinct targets, got %v", targets)
+			}
+			seen[v] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,517 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+// serveIndex is the page served at /, which plays a game through the state
+// endpoint
+//
+//go:embed serve.html
+var serveIndex []byte
+
+var (
+	ErrPreloadPolicy = errors.New("Error preload policy, expected block or warm")
+	ErrServeDetail   = errors.New("Error detail, expected full")
+	ErrServeFull     = errors.New("Error full, expected true or false")
+)
+
+const (
+	PreloadBlock = "block"
+	PreloadWarm  = "warm"
+)
+
+const (
+	maxServeBodyLen   = 1 << 16
+	defaultServeTopN  = 10
+	maxServeTopN      = 100
+	serveContentType  = "application/json"
+	serveSuggestRoute = "/v1/suggest"
+	serveHealthRoute  = "/v1/healthz"
+	serveStateRoute   = "/v1/state"
+	serveFilterRoute  = "/v1/filter"
+	// serveIndexRoute only matches / itself rather than every path
+	serveIndexRoute = "/{$}"
+	// maxStateCandidates bounds the remaining candidates listed in a state
+	maxStateCandidates = 20
+	// version 2 adds detail=full, which includes the priors and the
+	// probability that each suggestion is the answer
+	serveSchemaVersion = 2
+)
+
+type (
+	// Server answers suggestion requests statelessly, rebuilding the universe
+	// from the history supplied with each request
+	Server struct {
+		universe   wordle.Universe
+		index      *wordle.LetterIndex
+		guesses    []wordle.WordleWord
+		openerWord wordle.WordleWord
+		strategy   string
+		priors     string
+		hard       bool
+
+		// suggestions for an empty history are the same for every request and
+		// are the most expensive to compute
+		openerOnce sync.Once
+		opener     []wordle.ScoredGuess
+
+		// the preloaded artifacts are only read once ready is set
+		ready  atomic.Bool
+		matrix *wordle.PatternMatrix
+		book   map[wordle.WordlePattern][]wordle.ScoredGuess
+	}
+
+	SuggestRequest struct {
+		History []SuggestTurn `json:"history"`
+		TopN    int           `json:"top_n"`
+	}
+
+	SuggestTurn struct {
+		Guess   string `json:"guess"`
+		Pattern string `json:"pattern"`
+	}
+
+	SuggestResponse struct {
+		Version     int               `json:"version"`
+		Remaining   int               `json:"remaining"`
+		Priors      string            `json:"priors,omitempty"`
+		Suggestions []ServeSuggestion `json:"suggestions"`
+	}
+
+	// ServeSuggestion only includes the probability with detail=full
+	ServeSuggestion struct {
+		DumpScoredGuess
+		Probability *float64 `json:"probability,omitempty"`
+	}
+
+	FilterRequest struct {
+		History []SuggestTurn `json:"history"`
+		Sample  int           `json:"sample"`
+	}
+
+	// FilterResponse is the number of remaining candidates and a bounded
+	// sample of them. Unless exact is set, remaining is an upper bound which
+	// ignores how many times each letter occurs, and the sample may include
+	// words that a full filter would eliminate.
+	FilterResponse struct {
+		Version   int      `json:"version"`
+		Remaining int      `json:"remaining"`
+		Exact     bool     `json:"exact"`
+		Sample    []string `json:"sample"`
+	}
+
+	// StateResponse is the board, remaining candidates, and suggestions
+	// reached by the history of a request
+	StateResponse struct {
+		Version     int                 `json:"version"`
+		Remaining   int                 `json:"remaining"`
+		Board       []wordle.TurnResult `json:"board"`
+		Candidates  []string            `json:"candidates"`
+		Suggestions []ServeSuggestion   `json:"suggestions"`
+	}
+
+	HealthResponse struct {
+		Status string `json:"status"`
+	}
+)
+
+// NewServer creates a server which reports warming until either Preload or
+// MarkReady is called. openerWord is the opening the second guess book is
+// built for, and the best turn one suggestion is used if it is zero.
+func NewServer(universe wordle.Universe, guesses []wordle.WordleWord, openerWord wordle.WordleWord, strategy string, priors string, hard bool) *Server {
+	return &Server{
+		universe:   universe,
+		index:      wordle.NewLetterIndex(universe.Words()),
+		guesses:    guesses,
+		openerWord: openerWord,
+		strategy:   strategy,
+		priors:     priors,
+		hard:       hard,
+	}
+}
+
+func ValidPreloadPolicy(policy string) error {
+	if policy != PreloadBlock && policy != PreloadWarm {
+		return fmt.Errorf("%w: %s", ErrPreloadPolicy, policy)
+	}
+	return nil
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(serveSuggestRoute, s.handleSuggest)
+	mux.HandleFunc(serveHealthRoute, s.handleHealth)
+	mux.HandleFunc(serveStateRoute, s.handleState)
+	mux.HandleFunc(serveFilterRoute, s.handleFilter)
+	mux.HandleFunc(serveIndexRoute, s.handleIndex)
+	return mux
+}
+
+func (s *Server) MarkReady() {
+	s.ready.Store(true)
+}
+
+// Preload builds the pattern table, the turn one suggestions, and the second
+// guess book in order, logging the time taken by each, and then marks the
+// server ready. The pattern table is loaded from cacheFile if it is not empty.
+func (s *Server) Preload(cacheFile string) {
+	var matrix *wordle.PatternMatrix
+	if s.universe.Len() <= wordle.MaxMatrixWordLen {
+		preloadStage("pattern table", func() {
+			if cacheFile != "" {
+				var err error
+				matrix, err = LoadPatternMatrix(cacheFile, s.guesses, s.universe.Words())
+				if err == nil {
+					return
+				}
+				log.Println(err)
+			}
+			matrix = wordle.NewPatternMatrix(s.guesses, s.universe.Words())
+		})
+	} else {
+		log.Printf("Skipping pattern table for words longer than %d letters", wordle.MaxMatrixWordLen)
+	}
+	preloadStage("turn one suggestions", func() {
+		s.openerOnce.Do(func() {
+			s.opener = s.rank(s.universe, matrix, maxServeTopN)
+		})
+	})
+	opener := s.openerWord
+	if opener.Len() == 0 && len(s.opener) > 0 {
+		opener = s.opener[0].Word
+	}
+	var book map[wordle.WordlePattern][]wordle.ScoredGuess
+	if opener.Len() != 0 {
+		preloadStage("second guess book for "+opener.String(), func() {
+			book = s.buildBook(opener, matrix)
+		})
+	}
+	s.matrix = matrix
+	s.book = book
+	s.MarkReady()
+}
+
+func preloadStage(name string, f func()) {
+	start := time.Now()
+	f()
+	log.Printf("Preloaded %s in %s", name, time.Since(start).Round(time.Millisecond))
+}
+
+// buildBook ranks the second guesses for every pattern the opener can
+// receive, spreading the patterns over a pool of workers
+func (s *Server) buildBook(opener wordle.WordleWord, matrix *wordle.PatternMatrix) map[wordle.WordlePattern][]wordle.ScoredGuess {
+	seen := map[wordle.WordlePattern]struct{}{}
+	var patterns []wordle.WordlePattern
+	for _, v := range s.universe.Candidates() {
+		pattern := v.ComputePattern(opener)
+		if _, ok := seen[pattern]; ok {
+			continue
+		}
+		seen[pattern] = struct{}{}
+		patterns = append(patterns, pattern)
+	}
+	book := make(map[wordle.WordlePattern][]wordle.ScoredGuess, len(patterns))
+	var mu sync.Mutex
+	jobs := make(chan wordle.WordlePattern)
+	var wg sync.WaitGroup
+	for range runtime.GOMAXPROCS(0) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pattern := range jobs {
+				ranked := s.rank(s.universe.ApplyPattern(pattern), matrix, maxServeTopN)
+				mu.Lock()
+				book[pattern] = ranked
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, v := range patterns {
+		jobs <- v
+	}
+	close(jobs)
+	wg.Wait()
+	return book
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	status := http.StatusOK
+	res := HealthResponse{
+		Status: "ready",
+	}
+	if !s.ready.Load() {
+		status = http.StatusServiceUnavailable
+		res.Status = "warming"
+	}
+	w.Header().Set("Content-Type", serveContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		log.Println(err)
+	}
+}
+
+func (s *Server) handleSuggest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeServeError(w, http.StatusMethodNotAllowed, errors.New("Method not allowed"))
+		return
+	}
+	detail := r.URL.Query().Get("detail")
+	if detail != "" && detail != "full" {
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("%w: %s", ErrServeDetail, detail))
+		return
+	}
+	req, ok := decodeSuggestRequest(w, r)
+	if !ok {
+		return
+	}
+	universe, history, _, ok := s.applyHistory(w, req.History)
+	if !ok {
+		return
+	}
+	res := SuggestResponse{
+		Version:   serveSchemaVersion,
+		Remaining: universe.Count(),
+	}
+	if detail == "full" {
+		res.Priors = s.priors
+	}
+	res.Suggestions = serveSuggestions(universe, s.suggest(universe, history, req.topN()), detail == "full")
+	writeServeJSON(w, res)
+}
+
+// handleState describes the game reached by the history of a request, so
+// that a client without a session can show the whole board in one round
+// trip
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeServeError(w, http.StatusMethodNotAllowed, errors.New("Method not allowed"))
+		return
+	}
+	req, ok := decodeSuggestRequest(w, r)
+	if !ok {
+		return
+	}
+	universe, history, turns, ok := s.applyHistory(w, req.History)
+	if !ok {
+		return
+	}
+	res := StateResponse{
+		Version:    serveSchemaVersion,
+		Remaining:  universe.Count(),
+		Board:      turns,
+		Candidates: []string{},
+	}
+	for _, v := range universe.Candidates()[:min(universe.Count(), maxStateCandidates)] {
+		res.Candidates = append(res.Candidates, v.String())
+	}
+	if universe.Count() > 0 && (len(history) == 0 || !history[len(history)-1].Solved()) {
+		res.Suggestions = serveSuggestions(universe, s.suggest(universe, history, req.topN()), false)
+	} else {
+		res.Suggestions = []ServeSuggestion{}
+	}
+	writeServeJSON(w, res)
+}
+
+// handleFilter counts the candidates left by the history of a request. By
+// default the count comes from intersecting the letter index, which bounds
+// the exact count from above without filtering every word, and full=true
+// forces exact enumeration.
+func (s *Server) handleFilter(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeServeError(w, http.StatusMethodNotAllowed, errors.New("Method not allowed"))
+		return
+	}
+	full := r.URL.Query().Get("full")
+	if full != "" && full != "true" && full != "false" {
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("%w: %s", ErrServeFull, full))
+		return
+	}
+	var req FilterRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxServeBodyLen)).Decode(&req); err != nil {
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("Invalid request body: %w", err))
+		return
+	}
+	sample := req.Sample
+	if sample <= 0 {
+		sample = defaultServeTopN
+	}
+	sample = min(sample, maxServeTopN)
+	res := FilterResponse{
+		Version: serveSchemaVersion,
+		Sample:  make([]string, 0, sample),
+	}
+	if full == "true" {
+		universe, _, _, ok := s.applyHistory(w, req.History)
+		if !ok {
+			return
+		}
+		res.Remaining = universe.Count()
+		res.Exact = true
+		for _, v := range universe.Candidates()[:min(universe.Count(), sample)] {
+			res.Sample = append(res.Sample, v.String())
+		}
+		writeServeJSON(w, res)
+		return
+	}
+	_, history, ok := s.parseHistory(w, req.History)
+	if !ok {
+		return
+	}
+	candidates := s.index.Filter(s.universe, history)
+	res.Remaining = candidates.Size()
+	words := s.universe.Words()
+	candidates.Range(func(i int) bool {
+		res.Sample = append(res.Sample, words[i].String())
+		return len(res.Sample) < sample
+	})
+	writeServeJSON(w, res)
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write(serveIndex); err != nil {
+		log.Println(err)
+	}
+}
+
+func decodeSuggestRequest(w http.ResponseWriter, r *http.Request) (SuggestRequest, bool) {
+	var req SuggestRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxServeBodyLen)).Decode(&req); err != nil {
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("Invalid request body: %w", err))
+		return SuggestRequest{}, false
+	}
+	return req, true
+}
+
+func (r SuggestRequest) topN() int {
+	if r.TopN <= 0 {
+		return defaultServeTopN
+	}
+	return min(r.TopN, maxServeTopN)
+}
+
+// parseHistory parses the turns of a request, writing an error response and
+// returning false if a turn is invalid
+func (s *Server) parseHistory(w http.ResponseWriter, turns []SuggestTurn) ([]wordle.WordleWord, []wordle.WordlePattern, bool) {
+	guesses := make([]wordle.WordleWord, 0, len(turns))
+	history := make([]wordle.WordlePattern, 0, len(turns))
+	for n, i := range turns {
+		guess, err := wordle.ParseWordLen(i.Guess, s.universe.Len())
+		if err != nil {
+			writeServeError(w, http.StatusBadRequest, fmt.Errorf("history %d guess %q: %w", n+1, i.Guess, err))
+			return nil, nil, false
+		}
+		pattern, err := wordle.ParseWordlePattern(guess, i.Pattern)
+		if err != nil {
+			writeServeError(w, http.StatusBadRequest, fmt.Errorf("history %d pattern %q: %w", n+1, i.Pattern, err))
+			return nil, nil, false
+		}
+		guesses = append(guesses, guess)
+		history = append(history, pattern)
+	}
+	return guesses, history, true
+}
+
+// applyHistory narrows the universe of the server by each turn of a
+// request, writing an error response and returning false if a turn is
+// invalid
+func (s *Server) applyHistory(w http.ResponseWriter, turns []SuggestTurn) (wordle.Universe, []wordle.WordlePattern, []wordle.TurnResult, bool) {
+	guesses, history, ok := s.parseHistory(w, turns)
+	if !ok {
+		return wordle.Universe{}, nil, nil, false
+	}
+	universe := s.universe
+	board := make([]wordle.TurnResult, 0, len(turns))
+	for n, pattern := range history {
+		universe = universe.Apply(guesses[n], pattern)
+		board = append(board, wordle.NewTurnResult(guesses[n], pattern, universe, 0))
+	}
+	return universe, history, board, true
+}
+
+func serveSuggestions(universe wordle.Universe, suggestions []wordle.ScoredGuess, full bool) []ServeSuggestion {
+	res := make([]ServeSuggestion, 0, len(suggestions))
+	for _, v := range suggestions {
+		k := ServeSuggestion{
+			DumpScoredGuess: DumpScoredGuess{
+				Word:              v.Word.String(),
+				Entropy:           v.Entropy,
+				ExpectedRemaining: v.ExpectedRemaining,
+				WorstCase:         v.WorstCase,
+				Candidate:         v.Candidate,
+			},
+		}
+		if full {
+			p := universe.AnswerProbability(v.Word)
+			k.Probability = &p
+		}
+		res = append(res, k)
+	}
+	return res
+}
+
+func (s *Server) suggest(universe wordle.Universe, history []wordle.WordlePattern, topN int) []wordle.ScoredGuess {
+	var matrix *wordle.PatternMatrix
+	if s.ready.Load() {
+		matrix = s.matrix
+		if len(history) == 1 {
+			if ranked, ok := s.book[history[0]]; ok {
+				return ranked[:min(topN, len(ranked))]
+			}
+		}
+	}
+	if len(history) != 0 {
+		return s.rank(universe, matrix, topN)
+	}
+	s.openerOnce.Do(func() {
+		s.opener = s.rank(universe, matrix, maxServeTopN)
+	})
+	return s.opener[:min(topN, len(s.opener))]
+}
+
+// rank scores with the pattern table when there is one, which is skipped in
+// hard mode where the pool depends on the universe
+func (s *Server) rank(universe wordle.Universe, matrix *wordle.PatternMatrix, topN int) []wordle.ScoredGuess {
+	if matrix == nil || s.hard {
+		pool := s.guesses
+		if s.hard {
+			pool = universe.HardModeGuesses(pool)
+		}
+		return SuggestGuesses(s.strategy, universe, pool, topN)
+	}
+	candidates := matrix.CandidateIndices(universe)
+	if s.strategy == "minimax" {
+		return matrix.RankGuessesMinimax(candidates, topN)
+	}
+	return matrix.RankGuesses(candidates, topN)
+}
+
+func writeServeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", serveContentType)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println(err)
+	}
+}
+
+func writeServeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", serveContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(wordle.NewErrorResult(err)); err != nil {
+		log.Println(err)
+	}
+}
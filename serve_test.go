@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func getHealth(t *testing.T, url string) (int, string) {
+	t.Helper()
+	res, err := http.Get(url + serveHealthRoute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var body HealthResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode, body.Status
+}
+
+func TestServerWarming(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "fakes", "makes", "takes")
+	guesses := append(mustWords(t, "tbcmz"), words...)
+	opener := mustWords(t, "tbcmz")[0]
+
+	for _, tc := range []struct {
+		Name  string
+		Ready func(s *Server)
+	}{
+		{Name: "preload", Ready: func(s *Server) { s.Preload("") }},
+		{Name: "mark ready", Ready: (*Server).MarkReady},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewServer(wordle.NewUniverse(words), guesses, opener, "entropy", "", false)
+			srv := httptest.NewServer(s.Handler())
+			defer srv.Close()
+
+			if status, body := getHealth(t, srv.URL); status != http.StatusServiceUnavailable || body != "warming" {
+				t.Fatalf("expected %d warming before preload, got %d %s", http.StatusServiceUnavailable, status, body)
+			}
+			tc.Ready(s)
+			if status, body := getHealth(t, srv.URL); status != http.StatusOK || body != "ready" {
+				t.Fatalf("expected %d ready after preload, got %d %s", http.StatusOK, status, body)
+			}
+		})
+	}
+}
+
+func TestServerSuggest(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "fakes", "makes", "takes")
+	guesses := append(mustWords(t, "cflmz", "tbcmz"), words...)
+	slices.SortFunc(guesses, wordle.WordleWord.Compare)
+	guess := mustWords(t, "bakes")[0]
+	pattern, err := wordle.ParseWordlePattern(guess, "bgggg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	universe := wordle.NewUniverse(words).Apply(guess, pattern)
+
+	for _, tc := range []struct {
+		Name    string
+		Detail  string
+		History []SuggestTurn
+		Preload bool
+	}{
+		{Name: "default", History: []SuggestTurn{{Guess: "bakes", Pattern: "bgggg"}}},
+		{Name: "full", Detail: "full", History: []SuggestTurn{{Guess: "bakes", Pattern: "bgggg"}}},
+		{Name: "full preloaded", Detail: "full", History: []SuggestTurn{{Guess: "bakes", Pattern: "bgggg"}}, Preload: true},
+		{Name: "no candidates", History: []SuggestTurn{{Guess: "bakes", Pattern: "bbbbb"}}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewServer(wordle.NewUniverse(words), guesses, wordle.WordleWord{}, "entropy", "uniform", false)
+			if tc.Preload {
+				s.Preload("")
+			}
+			srv := httptest.NewServer(s.Handler())
+			defer srv.Close()
+
+			body, err := json.Marshal(SuggestRequest{History: tc.History, TopN: 3})
+			if err != nil {
+				t.Fatal(err)
+			}
+			url := srv.URL + "/v1/suggest"
+			if tc.Detail != "" {
+				url += "?detail=" + tc.Detail
+			}
+			res, err := http.Post(url, serveContentType, bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, res.StatusCode)
+			}
+			raw, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got SuggestResponse
+			if err := json.Unmarshal(raw, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			u := universe
+			if tc.History[0].Pattern == "bbbbb" {
+				if !bytes.Contains(raw, []byte(`"suggestions":[]`)) {
+					t.Fatalf("expected an empty suggestions array, got %s", raw)
+				}
+				return
+			}
+			if got.Version != serveSchemaVersion || got.Remaining != u.Count() {
+				t.Fatalf("expected version %d remaining %d, got %d %d", serveSchemaVersion, u.Count(), got.Version, got.Remaining)
+			}
+			if full := tc.Detail == "full"; (got.Priors != "") != full || bytes.Contains(raw, []byte(`"probability"`)) != full {
+				t.Fatalf("expected priors and probability only with detail=full, got %s", raw)
+			}
+			want := SuggestGuesses("entropy", u, guesses, 3)
+			if len(got.Suggestions) != len(want) {
+				t.Fatalf("expected %d suggestions, got %d", len(want), len(got.Suggestions))
+			}
+			for n, v := range want {
+				k := got.Suggestions[n]
+				if k.Word != v.Word.String() || k.Candidate != v.Candidate || k.WorstCase != v.WorstCase ||
+					math.Abs(k.Entropy-v.Entropy) > 1e-9 || math.Abs(k.ExpectedRemaining-v.ExpectedRemaining) > 1e-9 {
+					t.Errorf("suggestion %d: expected %+v, got %+v", n, v, k.DumpScoredGuess)
+				}
+				if k.Probability != nil && *k.Probability != u.AnswerProbability(v.Word) {
+					t.Errorf("suggestion %d: expected probability %v, got %v", n, u.AnswerProbability(v.Word), *k.Probability)
+				}
+			}
+		})
+	}
+}
+
+func postServe(t *testing.T, url string, req SuggestRequest) (int, []byte) {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.Post(url, serveContentType, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode, raw
+}
+
+func TestServerState(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "fakes", "makes", "takes")
+	guesses := append(mustWords(t, "cflmz", "tbcmz"), words...)
+	slices.SortFunc(guesses, wordle.WordleWord.Compare)
+	s := NewServer(wordle.NewUniverse(words), guesses, wordle.WordleWord{}, "entropy", "", false)
+	s.MarkReady()
+	srv := httptest.NewServer(s.Handler())
+	// the subtests run in parallel after this function returns
+	t.Cleanup(srv.Close)
+
+	for _, tc := range []struct {
+		Name    string
+		History []SuggestTurn
+		Status  int
+		Board   []string
+	}{
+		{Name: "empty", Status: http.StatusOK},
+		{Name: "one turn", History: []SuggestTurn{{Guess: "bakes", Pattern: "bgggg"}}, Status: http.StatusOK, Board: []string{"BGGGG"}},
+		{
+			Name:    "solved",
+			History: []SuggestTurn{{Guess: "bakes", Pattern: "bgggg"}, {Guess: "cakes", Pattern: "ggggg"}},
+			Status:  http.StatusOK,
+			Board:   []string{"BGGGG", "GGGGG"},
+		},
+		{Name: "no candidates", History: []SuggestTurn{{Guess: "bakes", Pattern: "bbbbb"}}, Status: http.StatusOK, Board: []string{"BBBBB"}},
+		{Name: "invalid guess", History: []SuggestTurn{{Guess: "bake", Pattern: "bggg"}}, Status: http.StatusBadRequest},
+		{Name: "invalid pattern", History: []SuggestTurn{{Guess: "bakes", Pattern: "bgxgg"}}, Status: http.StatusBadRequest},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			status, raw := postServe(t, srv.URL+serveStateRoute, SuggestRequest{History: tc.History, TopN: 3})
+			if status != tc.Status {
+				t.Fatalf("expected status %d, got %d %s", tc.Status, status, raw)
+			}
+			if status != http.StatusOK {
+				return
+			}
+			var got StateResponse
+			if err := json.Unmarshal(raw, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			// the same state computed with the library
+			universe := wordle.NewUniverse(words)
+			solved := false
+			for _, v := range tc.History {
+				guess := mustWords(t, v.Guess)[0]
+				pattern, err := wordle.ParseWordlePattern(guess, v.Pattern)
+				if err != nil {
+					t.Fatal(err)
+				}
+				universe = universe.Apply(guess, pattern)
+				solved = pattern.Solved()
+			}
+			if got.Version != serveSchemaVersion || got.Remaining != universe.Count() {
+				t.Fatalf("expected version %d remaining %d, got %d %d", serveSchemaVersion, universe.Count(), got.Version, got.Remaining)
+			}
+			var board []string
+			for _, v := range got.Board {
+				marks := ""
+				for _, m := range v.Pattern {
+					marks += m.Mark
+				}
+				board = append(board, marks)
+			}
+			if !slices.Equal(board, tc.Board) {
+				t.Fatalf("expected board %v, got %v", tc.Board, board)
+			}
+			var candidates []string
+			for _, v := range universe.Candidates() {
+				candidates = append(candidates, v.String())
+			}
+			if len(got.Candidates) != len(candidates) || len(candidates) != 0 && !slices.Equal(got.Candidates, candidates) {
+				t.Fatalf("expected candidates %v, got %v", candidates, got.Candidates)
+			}
+			var want []wordle.ScoredGuess
+			if universe.Count() > 0 && !solved {
+				want = SuggestGuesses("entropy", universe, guesses, 3)
+			}
+			if len(got.Suggestions) != len(want) || got.Suggestions == nil {
+				t.Fatalf("expected %d suggestions, got %s", len(want), raw)
+			}
+			for n, v := range want {
+				if k := got.Suggestions[n]; k.Word != v.Word.String() || math.Abs(k.Entropy-v.Entropy) > 1e-9 {
+					t.Errorf("suggestion %d: expected %+v, got %+v", n, v, k.DumpScoredGuess)
+				}
+			}
+		})
+	}
+}
+
+func TestServerIndex(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer(wordle.NewUniverse(mustWords(t, "bakes", "cakes")), mustWords(t, "bakes", "cakes"), wordle.WordleWord{}, "entropy", "", false)
+	srv := httptest.NewServer(s.Handler())
+	// the subtests run in parallel after this function returns
+	t.Cleanup(srv.Close)
+
+	for _, tc := range []struct {
+		Path   string
+		Status int
+	}{
+		{Path: "/", Status: http.StatusOK},
+		{Path: "/missing", Status: http.StatusNotFound},
+		// suggestions are only served under the versioned route
+		{Path: "/suggest", Status: http.StatusNotFound},
+	} {
+		t.Run(tc.Path, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := http.Get(srv.URL + tc.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tc.Status {
+				t.Fatalf("expected status %d, got %d", tc.Status, res.StatusCode)
+			}
+			if tc.Status != http.StatusOK {
+				return
+			}
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ct := res.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+				t.Fatalf("expected an html page, got %s", ct)
+			}
+			// the page only talks to the server it was loaded from
+			if !bytes.Contains(body, []byte(`fetch("`+serveStateRoute+`"`)) {
+				t.Fatal("expected the page to fetch the state endpoint")
+			}
+		})
+	}
+}
+
+func TestServerFilter(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "abide", "bakes", "cakes", "eerie", "geese", "label", "speed")
+	s := NewServer(wordle.NewUniverse(words), words, wordle.WordleWord{}, "entropy", "", false)
+	srv := httptest.NewServer(s.Handler())
+	t.Cleanup(srv.Close)
+
+	// eerie gets YYBBB against speed, which leaves only speed once the
+	// number of Es is counted
+	history := []SuggestTurn{{Guess: "eerie", Pattern: "yybbb"}}
+	for _, tc := range []struct {
+		Name      string
+		Query     string
+		History   []SuggestTurn
+		Sample    int
+		Status    int
+		Remaining int
+		Exact     bool
+		Words     []string
+	}{
+		{Name: "fast", History: history, Status: http.StatusOK, Remaining: 4, Words: []string{"BAKES", "CAKES", "LABEL", "SPEED"}},
+		{Name: "fast sample", History: history, Sample: 2, Status: http.StatusOK, Remaining: 4, Words: []string{"BAKES", "CAKES"}},
+		{Name: "full", Query: "?full=true", History: history, Status: http.StatusOK, Remaining: 1, Exact: true, Words: []string{"SPEED"}},
+		{Name: "full sample", Query: "?full=true", Sample: 2, Status: http.StatusOK, Remaining: 7, Exact: true, Words: []string{"ABIDE", "BAKES"}},
+		{Name: "no history", Status: http.StatusOK, Remaining: 7, Words: []string{"ABIDE", "BAKES", "CAKES", "EERIE", "GEESE", "LABEL", "SPEED"}},
+		{Name: "invalid full", Query: "?full=yes", History: history, Status: http.StatusBadRequest},
+		{Name: "invalid pattern", History: []SuggestTurn{{Guess: "eerie", Pattern: "yy"}}, Status: http.StatusBadRequest},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			body, err := json.Marshal(FilterRequest{History: tc.History, Sample: tc.Sample})
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := http.Post(srv.URL+serveFilterRoute+tc.Query, serveContentType, bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tc.Status {
+				t.Fatalf("expected status %d, got %d", tc.Status, res.StatusCode)
+			}
+			if tc.Status != http.StatusOK {
+				return
+			}
+			var got FilterResponse
+			if err := json.NewDecoder(res.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Remaining != tc.Remaining || got.Exact != tc.Exact || !slices.Equal(got.Sample, tc.Words) {
+				t.Fatalf("expected %d %t %v, got %d %t %v", tc.Remaining, tc.Exact, tc.Words, got.Remaining, got.Exact, got.Sample)
+			}
+		})
+	}
+}
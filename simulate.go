@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+const (
+	maxGuesses = 6
+)
+
+func NewStrategy(name string, pool []wordle.WordleWord, hard bool) (wordle.Strategy, error) {
+	switch name {
+	case "first":
+		return wordle.FirstCandidateStrategy{}, nil
+	case "entropy":
+		if hard {
+			return wordle.NewHardModeEntropyStrategy(pool), nil
+		}
+		return wordle.NewEntropyStrategy(pool), nil
+	case "minimax":
+		if hard {
+			return wordle.NewHardModeMinimaxStrategy(pool), nil
+		}
+		return wordle.NewMinimaxStrategy(pool), nil
+	default:
+		return nil, fmt.Errorf("Unknown strategy %q, expected first, entropy, or minimax", name)
+	}
+}
+
+// SuggestionStrategy is the ranking used for interactive suggestions, which
+// is entropy unless minimax is selected
+func SuggestionStrategy(name string) string {
+	if name == "minimax" {
+		return "minimax"
+	}
+	return "entropy"
+}
+
+func SuggestGuesses(strategy string, universe wordle.Universe, pool []wordle.WordleWord, topN int) []wordle.ScoredGuess {
+	if strategy == "minimax" {
+		return wordle.SuggestGuessesMinimax(universe, pool, topN)
+	}
+	return wordle.SuggestGuesses(universe, pool, topN)
+}
+
+func ReadWordFile(name string, n int) ([]wordle.WordleWord, error) {
+	words, _, err := ReadWordList(name, wordle.LoadOpts{
+		StripAnnotations: wordle.DefaultStripAnnotations,
+		Len:              n,
+	})
+	return words, err
+}
+
+// ReadTargetsFile reads the targets of -simulate-all, each of which must be
+// in the sorted answer list
+func ReadTargetsFile(name string, answers []wordle.WordleWord) ([]wordle.WordleWord, error) {
+	targets, err := ReadWordFile(name, answers[0].Len())
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, v := range targets {
+		if _, ok := slices.BinarySearchFunc(answers, v, wordle.WordleWord.Compare); !ok {
+			missing = append(missing, v.String())
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s in %s", ErrNotAnswer, strings.Join(missing, ", "), name)
+	}
+	return targets, nil
+}
+
+func WriteSimulationResult(w io.Writer, res wordle.SimulationResult, format string) error {
+	if format == "json" {
+		return json.NewEncoder(w).Encode(res)
+	}
+	fmt.Fprintf(w, "strategy %s, %d games in %s\n", res.Strategy, res.Games, res.Runtime)
+	fmt.Fprintf(w, "average %.4f guesses\n", res.Average)
+	for n, v := range res.Histogram {
+		fmt.Fprintf(w, "%d: %d\n", n+1, v)
+	}
+	fmt.Fprintf(w, "%d+: %d\n", len(res.Histogram)+1, res.Failures)
+	fmt.Fprintf(w, "worst %d guesses: %s\n", res.WorstGuesses, strings.Join(res.Worst, " "))
+	return nil
+}
+
+type (
+	openingJSON struct {
+		Words             []string `json:"words"`
+		ExpectedRemaining float64  `json:"expected_remaining"`
+		ExpectedTotal     float64  `json:"expected_total"`
+		Identified        float64  `json:"identified"`
+	}
+)
+
+func WriteOpenings(w io.Writer, openings []wordle.OpeningScore, boards int, format string) error {
+	if format == "json" {
+		res := make([]openingJSON, 0, len(openings))
+		for _, v := range openings {
+			words := make([]string, 0, len(v.Words))
+			for _, i := range v.Words {
+				words = append(words, i.String())
+			}
+			res = append(res, openingJSON{
+				Words:             words,
+				ExpectedRemaining: v.ExpectedRemaining,
+				ExpectedTotal:     v.ExpectedTotal,
+				Identified:        v.Identified,
+			})
+		}
+		return json.NewEncoder(w).Encode(res)
+	}
+	for n, v := range openings {
+		words := make([]string, 0, len(v.Words))
+		for _, i := range v.Words {
+			words = append(words, i.String())
+		}
+		fmt.Fprintf(w, "%2d. %s: %.4f expected per board, %.4f over %d boards, %.2f%% identified\n", n+1, strings.Join(words, " "), v.ExpectedRemaining, v.ExpectedTotal, boards, v.Identified*100)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadTargetsFile(t *testing.T) {
+	t.Parallel()
+
+	answers := mustWords(t, "bakes", "cakes", "fakes")
+
+	for _, tc := range []struct {
+		Name     string
+		Data     string
+		Expected []string
+		Msg      string
+	}{
+		{
+			Name:     "answers",
+			Data:     "cakes\nbakes\n",
+			Expected: []string{"BAKES", "CAKES"},
+		},
+		{
+			Name: "guess only words",
+			Data: "cakes\naahed\nzonal\n",
+			Msg:  "AAHED, ZONAL",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			name := filepath.Join(t.TempDir(), "targets.txt")
+			if err := os.WriteFile(name, []byte(tc.Data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			targets, err := ReadTargetsFile(name, answers)
+			if tc.Msg != "" {
+				if !errors.Is(err, ErrNotAnswer) || !strings.Contains(err.Error(), tc.Msg) {
+					t.Fatalf("expected error containing %q, got %v", tc.Msg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(targets))
+			for _, v := range targets {
+				got = append(got, v.String())
+			}
+			if !slices.Equal(got, tc.Expected) {
+				t.Fatalf("expected %v, got %v", tc.Expected, got)
+			}
+		})
+	}
+}
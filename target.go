@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+var (
+	ErrNotAnswer       = errors.New("Error target not in answer list")
+	ErrAmbiguousTarget = errors.New("Error ambiguous target correction")
+	ErrTargetRejected  = errors.New("Error target correction rejected")
+)
+
+// ResolveTarget parses a target and corrects a single typo against the
+// answer list, prompting for confirmation unless fuzzy is set
+func ResolveTarget(s string, words []wordle.WordleWord, fuzzy bool) (wordle.WordleWord, error) {
+	w, err := wordle.ParseWordLen(s, words[0].Len())
+	if err != nil {
+		return wordle.WordleWord{}, err
+	}
+	if _, ok := slices.BinarySearchFunc(words, w, wordle.WordleWord.Compare); ok {
+		return w, nil
+	}
+	k := wordle.Neighbors(w, words)
+	switch len(k) {
+	case 0:
+		return wordle.WordleWord{}, fmt.Errorf("%w: %s", ErrNotAnswer, w.String())
+	case 1:
+	default:
+		opts := make([]string, 0, len(k))
+		for _, i := range k {
+			opts = append(opts, i.String())
+		}
+		return wordle.WordleWord{}, fmt.Errorf("%w: %s could be %s", ErrAmbiguousTarget, w.String(), strings.Join(opts, ", "))
+	}
+	if fuzzy {
+		fmt.Fprintf(os.Stderr, "Correcting target %s to %s\n", w.String(), k[0].String())
+		return k[0], nil
+	}
+	fmt.Printf("%s is not in the answer list, did you mean %s? [Y/n] ", w.String(), k[0].String())
+	// stdin is read a byte at a time so that no game input is buffered here
+	line, err := ReadLine(bufio.NewReader(byteReader{r: os.Stdin}))
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return wordle.WordleWord{}, ErrTargetRejected
+		}
+		return wordle.WordleWord{}, err
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "", "y", "yes":
+		return k[0], nil
+	default:
+		return wordle.WordleWord{}, ErrTargetRejected
+	}
+}
+
+type (
+	byteReader struct {
+		r io.Reader
+	}
+)
+
+func (b byteReader) Read(p []byte) (int, error) {
+	if len(p) > 1 {
+		p = p[:1]
+	}
+	return b.r.Read(p)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func TestResolveTarget(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "crane", "stool")
+
+	for _, tc := range []struct {
+		Name   string
+		Target string
+		Want   string
+		Err    error
+		Msg    string
+	}{
+		{Name: "exact", Target: "crane", Want: "CRANE"},
+		{Name: "substitution", Target: "stoop", Want: "STOOL"},
+		{Name: "transposition", Target: "carne", Want: "CRANE"},
+		{Name: "ambiguous", Target: "lakes", Err: ErrAmbiguousTarget, Msg: "Error ambiguous target correction: LAKES could be BAKES, CAKES"},
+		{Name: "no match", Target: "plumb", Err: ErrNotAnswer, Msg: "Error target not in answer list: PLUMB"},
+		{Name: "wrong length", Target: "cranes", Err: wordle.ErrWordLen},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			// fuzzy corrections are made without a prompt
+			w, err := ResolveTarget(tc.Target, words, true)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+			if err != nil {
+				if tc.Msg != "" && err.Error() != tc.Msg {
+					t.Fatalf("expected error %q, got %q", tc.Msg, err.Error())
+				}
+				return
+			}
+			if got := w.String(); got != tc.Want {
+				t.Fatalf("expected %s, got %s", tc.Want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+var ErrTournamentSpec = errors.New("Error invalid tournament spec")
+
+const (
+	// sign tests below this p value are marked significant
+	tournamentSignificance = 0.05
+)
+
+type (
+	// TournamentSpec lists the solver profiles of a tournament
+	TournamentSpec struct {
+		Profiles []ProfileSpec `json:"profiles"`
+	}
+
+	// ProfileSpec configures a solver, and is named after its strategy if
+	// name is empty
+	ProfileSpec struct {
+		Name     string `json:"name"`
+		Strategy string `json:"strategy"`
+		Hard     bool   `json:"hard"`
+		Opener   string `json:"opener"`
+	}
+
+	TournamentStanding struct {
+		Profile  string
+		Average  float64
+		Failures int
+		Guesses  []int
+	}
+)
+
+func ReadTournamentSpec(name string) (*TournamentSpec, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading tournament spec %s: %w", name, err)
+	}
+	var spec TournamentSpec
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, fmt.Errorf("Invalid tournament spec %s: %w", name, err)
+	}
+	if len(spec.Profiles) < 2 {
+		return nil, fmt.Errorf("%w: expected at least 2 profiles", ErrTournamentSpec)
+	}
+	seen := map[string]struct{}{}
+	for i := range spec.Profiles {
+		p := &spec.Profiles[i]
+		if p.Name == "" {
+			p.Name = p.Strategy
+			if p.Hard {
+				p.Name += "-hard"
+			}
+			if p.Opener != "" {
+				p.Name += "+" + p.Opener
+			}
+		}
+		if _, ok := seen[p.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate profile %s", ErrTournamentSpec, p.Name)
+		}
+		seen[p.Name] = struct{}{}
+	}
+	return &spec, nil
+}
+
+// NewProfileStrategy constructs the solver of a profile. Every game of a
+// tournament starts from the same universe, so the opening guess is computed
+// once and shared by all of its games.
+func NewProfileStrategy(p ProfileSpec, guesses []wordle.WordleWord, universe wordle.Universe) (wordle.Strategy, error) {
+	strategy, err := NewStrategy(p.Strategy, guesses, p.Hard)
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: %w", p.Name, err)
+	}
+	if p.Opener == "" {
+		return wordle.NewOpenerStrategy(strategy, strategy.NextGuess(universe, 0)), nil
+	}
+	opener, err := wordle.ParseWordLen(p.Opener, universe.Len())
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: %w", p.Name, err)
+	}
+	if _, ok := slices.BinarySearchFunc(guesses, opener, wordle.WordleWord.Compare); !ok {
+		return nil, fmt.Errorf("profile %s: %w", p.Name, ErrNotGuess)
+	}
+	return wordle.NewOpenerStrategy(strategy, opener), nil
+}
+
+// PlayTournament plays every strategy against every target, spreading the
+// games over a pool of workers
+func PlayTournament(names []string, strategies []wordle.Strategy, targets []wordle.WordleWord, universe wordle.Universe) []TournamentStanding {
+	standings := make([]TournamentStanding, len(strategies))
+	type game struct {
+		profile, target int
+	}
+	jobs := make(chan game)
+	var wg sync.WaitGroup
+	for i := range standings {
+		standings[i] = TournamentStanding{
+			Profile: names[i],
+			Guesses: make([]int, len(targets)),
+		}
+	}
+	for range runtime.GOMAXPROCS(0) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for g := range jobs {
+				standings[g.profile].Guesses[g.target] = wordle.PlayGame(targets[g.target], strategies[g.profile], universe)
+			}
+		}()
+	}
+	for i := range strategies {
+		for j := range targets {
+			jobs <- game{
+				profile: i,
+				target:  j,
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	for i := range standings {
+		s := &standings[i]
+		total := 0
+		for _, v := range s.Guesses {
+			total += v
+			if v > maxGuesses {
+				s.Failures++
+			}
+		}
+		if len(s.Guesses) > 0 {
+			s.Average = float64(total) / float64(len(s.Guesses))
+		}
+	}
+	return standings
+}
+
+func compareStandings(a, b TournamentStanding) int {
+	if c := cmp.Compare(a.Average, b.Average); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Failures, b.Failures)
+}
+
+// SignTest returns the games in which a took fewer and more guesses than b,
+// and the two sided sign test p value of the difference, ignoring ties
+func SignTest(a, b []int) (int, int, float64) {
+	better, worse := 0, 0
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			better++
+		case a[i] > b[i]:
+			worse++
+		}
+	}
+	n := better + worse
+	k := min(better, worse)
+	// p is twice the probability of k or fewer successes in n fair trials
+	p := 0.0
+	for i := 0; i <= k; i++ {
+		p += math.Exp(logChoose(n, i) - float64(n)*math.Ln2)
+	}
+	return better, worse, min(1, 2*p)
+}
+
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+func writeTournamentCSV(name string, standings []TournamentStanding, targets []wordle.WordleWord) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("Failed creating tournament results %s: %w", name, err)
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"profile", "game", "target", "guesses", "solved"}); err != nil {
+		return err
+	}
+	for _, s := range standings {
+		for i, v := range s.Guesses {
+			if err := w.Write([]string{s.Profile, strconv.Itoa(i + 1), targets[i].String(), strconv.Itoa(v), strconv.FormatBool(v <= maxGuesses)}); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func TournamentCmd(args []string) error {
+	fs := flag.NewFlagSet("tournament", flag.ExitOnError)
+	var specFile string
+	fs.StringVar(&specFile, "spec", "", "JSON tournament spec listing solver profiles")
+	var games int
+	fs.IntVar(&games, "games", 100, "number of random targets played by every profile")
+	var seed int64
+	fs.Int64Var(&seed, "seed", 1, "seed for the random targets")
+	var csvFile string
+	fs.StringVar(&csvFile, "csv", "", "write the result of every game to a csv file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if specFile == "" {
+		return errors.New("Usage: tournament -spec <file> [-games <n>] [-seed <n>] [-csv <file>]")
+	}
+	if games < 1 {
+		return errors.New("-games must be at least 1")
+	}
+	spec, err := ReadTournamentSpec(specFile)
+	if err != nil {
+		return err
+	}
+	words, err := wordle.DefaultWords()
+	if err != nil {
+		return err
+	}
+	guesses, err := wordle.DefaultGuesses()
+	if err != nil {
+		return err
+	}
+	guesses = wordle.MergeWords(words, guesses)
+	universe := wordle.NewUniverse(words)
+
+	names := make([]string, 0, len(spec.Profiles))
+	strategies := make([]wordle.Strategy, 0, len(spec.Profiles))
+	for _, p := range spec.Profiles {
+		strategy, err := NewProfileStrategy(p, guesses, universe)
+		if err != nil {
+			return err
+		}
+		names = append(names, p.Name)
+		strategies = append(strategies, strategy)
+	}
+	r := rand.New(rand.NewPCG(uint64(seed), 0))
+	targets := make([]wordle.WordleWord, 0, games)
+	for range games {
+		targets = append(targets, words[r.IntN(len(words))])
+	}
+
+	standings := PlayTournament(names, strategies, targets, universe)
+	if csvFile != "" {
+		if err := writeTournamentCSV(csvFile, standings, targets); err != nil {
+			return err
+		}
+	}
+	slices.SortStableFunc(standings, compareStandings)
+	fmt.Printf("%d games, seed %d\n", games, seed)
+	for i, s := range standings {
+		fmt.Printf("%d. %s average %.4f guesses, %d failures\n", i+1, s.Profile, s.Average, s.Failures)
+	}
+	fmt.Println()
+	for i, a := range standings {
+		for _, b := range standings[i+1:] {
+			better, worse, p := SignTest(a.Guesses, b.Guesses)
+			mark := ""
+			if p < tournamentSignificance {
+				mark = " *"
+			}
+			fmt.Printf("%s vs %s: %d better, %d worse, p %.4f%s\n", a.Profile, b.Profile, better, worse, p, mark)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func TestNewProfileStrategy(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "fakes", "makes", "takes")
+	guesses := mustWords(t, "bakes", "cakes", "cflmz", "fakes", "makes", "takes", "tbcmz")
+	universe := wordle.NewUniverse(words)
+
+	for _, tc := range []struct {
+		Name    string
+		Profile ProfileSpec
+		Opener  string
+		Err     error
+	}{
+		{Name: "computed", Profile: ProfileSpec{Name: "entropy", Strategy: "entropy"}, Opener: "TBCMZ"},
+		{Name: "configured", Profile: ProfileSpec{Name: "entropy+cakes", Strategy: "entropy", Opener: "cakes"}, Opener: "CAKES"},
+		{Name: "first", Profile: ProfileSpec{Name: "first", Strategy: "first"}, Opener: "BAKES"},
+		{Name: "not a guess", Profile: ProfileSpec{Name: "entropy+lakes", Strategy: "entropy", Opener: "lakes"}, Err: ErrNotGuess},
+		{Name: "wrong length", Profile: ProfileSpec{Name: "entropy+cake", Strategy: "entropy", Opener: "cake"}, Err: wordle.ErrWordLen},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			strategy, err := NewProfileStrategy(tc.Profile, guesses, universe)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := strategy.NextGuess(universe, 0).String(); got != tc.Opener {
+				t.Fatalf("expected opener %s, got %s", tc.Opener, got)
+			}
+		})
+	}
+}
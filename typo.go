@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func ReadKeyboardLayout(name string) (*wordle.KeyboardLayout, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading keyboard layout %s: %w", name, err)
+	}
+	defer f.Close()
+	k, err := wordle.ParseKeyboardLayout(f)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid keyboard layout %s: %w", name, err)
+	}
+	return k, nil
+}
+
+// confirmTypo offers the guesses one adjacent key away from a word which is
+// not in the guess list, and returns the guess that was chosen
+func confirmTypo(guess wordle.WordleWord, guesses []wordle.WordleWord, keyboard *wordle.KeyboardLayout, blocklist *Blocklist, input *InputReader) (wordle.WordleWord, error) {
+	if _, ok := slices.BinarySearchFunc(guesses, guess, wordle.WordleWord.Compare); ok {
+		return guess, nil
+	}
+	typos := blocklist.Filter(keyboard.AdjacentTypos(guess, guesses))
+	if len(typos) == 0 {
+		return guess, nil
+	}
+	if len(typos) == 1 {
+		fmt.Printf("%s is not in the guess list, did you mean %s? [Y/n] ", guess, typos[0])
+	} else {
+		k := make([]string, 0, len(typos))
+		for _, v := range typos {
+			k = append(k, v.String())
+		}
+		fmt.Printf("%s is not in the guess list, did you mean %s? [word/N] ", guess, strings.Join(k, " or "))
+	}
+	line, err := input.ReadLine()
+	if err != nil {
+		return wordle.WordleWord{}, err
+	}
+	answer := strings.ToLower(line)
+	if len(typos) == 1 {
+		if answer == "" || answer == "y" || answer == "yes" {
+			return typos[0], nil
+		}
+		return guess, nil
+	}
+	if w, err := wordle.ParseWord(answer); err == nil && slices.Contains(typos, w) {
+		return w, nil
+	}
+	return guess, nil
+}
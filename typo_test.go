@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func TestConfirmTypo(t *testing.T) {
+	t.Parallel()
+
+	// bakes is a guess but not an answer, and is one adjacent key from cakes
+	guesses := mustWords(t, "bakes", "cakes", "crane", "crate", "fakes")
+	blocklist := &Blocklist{
+		words: map[wordle.WordleWord]struct{}{
+			mustWords(t, "fakes")[0]: {},
+		},
+	}
+
+	for _, tc := range []struct {
+		Name   string
+		Guess  string
+		Input  string
+		Chosen string
+	}{
+		{Name: "guess not in the answers", Guess: "bakes", Chosen: "bakes"},
+		{Name: "valid guess", Guess: "crane", Chosen: "crane"},
+		{Name: "accepted", Guess: "crabe", Input: "y\n", Chosen: "crane"},
+		{Name: "accepted by default", Guess: "crabe", Input: "\n", Chosen: "crane"},
+		{Name: "rejected", Guess: "crabe", Input: "n\n", Chosen: "crabe"},
+		{Name: "chosen of several", Guess: "vakes", Input: "cakes\n", Chosen: "cakes"},
+		{Name: "none of several", Guess: "vakes", Input: "n\n", Chosen: "vakes"},
+		{Name: "blocked correction", Guess: "rakes", Chosen: "rakes"},
+		{Name: "not adjacent", Guess: "crake", Chosen: "crake"},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			// an empty input fails with EOF if a correction is offered
+			input := NewInputReader(strings.NewReader(tc.Input))
+			guess, err := confirmTypo(mustWords(t, tc.Guess)[0], guesses, wordle.QWERTYLayout(), blocklist, input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := mustWords(t, tc.Chosen)[0]; guess != want {
+				t.Fatalf("expected %s, got %s", want, guess)
+			}
+		})
+	}
+}
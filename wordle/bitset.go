@@ -0,0 +1,197 @@
+package wordle
+
+import (
+	"math/bits"
+	"slices"
+)
+
+type (
+	// BitSet is a set of non negative indices. Contains and Remove report
+	// false for indices beyond the allocated size, and Set and Insert grow the
+	// set to hold them. Negative indices are never members. Union, Intersect,
+	// and Difference return new sets and leave their operands unchanged.
+	BitSet struct {
+		bits []uint64
+		size int
+	}
+)
+
+func NewBitSet(size int) *BitSet {
+	return &BitSet{
+		bits: make([]uint64, (size+63)/64),
+		size: 0,
+	}
+}
+
+func (s *BitSet) Reset() {
+	for i := range s.bits {
+		s.bits[i] = 0
+	}
+	s.size = 0
+}
+
+func (s *BitSet) Size() int {
+	return s.size
+}
+
+func (s *BitSet) inRange(i int) bool {
+	return i >= 0 && i/64 < len(s.bits)
+}
+
+// grow extends the set to hold index i
+func (s *BitSet) grow(i int) {
+	if a := i / 64; a >= len(s.bits) {
+		s.bits = append(s.bits, make([]uint64, a+1-len(s.bits))...)
+	}
+}
+
+func (s *BitSet) Contains(i int) bool {
+	if !s.inRange(i) {
+		return false
+	}
+	a := i / 64
+	mask := uint64(1) << (i % 64)
+	return (s.bits[a] & mask) != 0
+}
+
+func (s *BitSet) Set(i int, b bool) bool {
+	if b {
+		return s.Insert(i)
+	}
+	return s.Remove(i)
+}
+
+func (s *BitSet) Insert(i int) bool {
+	if i < 0 {
+		return false
+	}
+	s.grow(i)
+	a := i / 64
+	mask := uint64(1) << (i % 64)
+	diff := (s.bits[a] & mask) == 0
+	s.bits[a] |= mask
+	if diff {
+		s.size++
+	}
+	return diff
+}
+
+func (s *BitSet) Remove(i int) bool {
+	if !s.inRange(i) {
+		return false
+	}
+	a := i / 64
+	mask := uint64(1) << (i % 64)
+	diff := (s.bits[a] & mask) != 0
+	s.bits[a] &^= mask
+	if diff {
+		s.size--
+	}
+	return diff
+}
+
+// Bytes returns the size of the backing array of the set
+func (s *BitSet) Bytes() int {
+	return cap(s.bits) * 8
+}
+
+func (s *BitSet) Clone() *BitSet {
+	return &BitSet{
+		bits: slices.Clone(s.bits),
+		size: s.size,
+	}
+}
+
+// Next returns the first set index at or after i, or -1 if there is none
+func (s *BitSet) Next(i int) int {
+	if i < 0 {
+		i = 0
+	}
+	a := i / 64
+	if a >= len(s.bits) {
+		return -1
+	}
+	// bits before i in the first word are masked off
+	w := s.bits[a] &^ (uint64(1)<<(i%64) - 1)
+	for {
+		if w != 0 {
+			return a*64 + bits.TrailingZeros64(w)
+		}
+		a++
+		if a >= len(s.bits) {
+			return -1
+		}
+		w = s.bits[a]
+	}
+}
+
+// Range calls f with each set index in increasing order until f returns
+// false
+func (s *BitSet) Range(f func(i int) bool) {
+	for a, w := range s.bits {
+		for w != 0 {
+			if !f(a*64 + bits.TrailingZeros64(w)) {
+				return
+			}
+			w &= w - 1
+		}
+	}
+}
+
+// ForEach calls f with each set index in increasing order
+func (s *BitSet) ForEach(f func(i int)) {
+	for a, w := range s.bits {
+		for w != 0 {
+			f(a*64 + bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+}
+
+// Slice returns the set indices in increasing order
+func (s *BitSet) Slice() []int {
+	k := make([]int, 0, s.size)
+	s.ForEach(func(i int) {
+		k = append(k, i)
+	})
+	return k
+}
+
+// combine returns a new set of the words of s and other combined by f, sized
+// to the longer of the two
+func (s *BitSet) combine(other *BitSet, f func(a, b uint64) uint64) *BitSet {
+	res := &BitSet{
+		bits: make([]uint64, max(len(s.bits), len(other.bits))),
+	}
+	for a := range res.bits {
+		var x, y uint64
+		if a < len(s.bits) {
+			x = s.bits[a]
+		}
+		if a < len(other.bits) {
+			y = other.bits[a]
+		}
+		res.bits[a] = f(x, y)
+		res.size += bits.OnesCount64(res.bits[a])
+	}
+	return res
+}
+
+func (s *BitSet) Union(other *BitSet) *BitSet {
+	return s.combine(other, func(a, b uint64) uint64 {
+		return a | b
+	})
+}
+
+func (s *BitSet) Intersect(other *BitSet) *BitSet {
+	return s.combine(other, func(a, b uint64) uint64 {
+		return a & b
+	})
+}
+
+// Difference returns the indices of s which are not in other
+func (s *BitSet) Difference(other *BitSet) *BitSet {
+	return s.combine(other, func(a, b uint64) uint64 {
+		return a &^ b
+	})
+}
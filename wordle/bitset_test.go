@@ -0,0 +1,224 @@
+package wordle
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func newTestBitSet(size int, indices ...int) *BitSet {
+	s := NewBitSet(size)
+	for _, v := range indices {
+		s.Insert(v)
+	}
+	return s
+}
+
+func TestBitSetIterate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name    string
+		Size    int
+		Indices []int
+	}{
+		{Name: "empty", Size: 256},
+		{Name: "word boundaries", Size: 256, Indices: []int{0, 63, 64, 127, 128, 255}},
+		{Name: "last index of a word", Size: 128, Indices: []int{63, 127}},
+		{Name: "first index of a word", Size: 192, Indices: []int{64, 128}},
+		{Name: "consecutive across a boundary", Size: 192, Indices: []int{62, 63, 64, 65, 126, 127, 128, 129}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newTestBitSet(tc.Size, tc.Indices...)
+			var ranged []int
+			s.Range(func(i int) bool {
+				ranged = append(ranged, i)
+				return true
+			})
+			var nexted []int
+			for i := s.Next(0); i >= 0; i = s.Next(i + 1) {
+				nexted = append(nexted, i)
+			}
+			if !reflect.DeepEqual(ranged, tc.Indices) {
+				t.Errorf("Range: expected %v, got %v", tc.Indices, ranged)
+			}
+			if !reflect.DeepEqual(nexted, tc.Indices) {
+				t.Errorf("Next: expected %v, got %v", tc.Indices, nexted)
+			}
+		})
+	}
+}
+
+func TestBitSetNext(t *testing.T) {
+	t.Parallel()
+
+	s := newTestBitSet(256, 63, 64, 127, 128)
+	for _, tc := range []struct {
+		After int
+		Next  int
+	}{
+		{After: -1, Next: 63},
+		{After: 0, Next: 63},
+		{After: 63, Next: 63},
+		{After: 64, Next: 64},
+		{After: 65, Next: 127},
+		{After: 127, Next: 127},
+		{After: 128, Next: 128},
+		{After: 129, Next: -1},
+		{After: 256, Next: -1},
+		{After: 1024, Next: -1},
+	} {
+		t.Run(fmt.Sprint(tc.After), func(t *testing.T) {
+			t.Parallel()
+
+			if got := s.Next(tc.After); got != tc.Next {
+				t.Fatalf("expected %d, got %d", tc.Next, got)
+			}
+		})
+	}
+}
+
+func TestBitSetRangeStop(t *testing.T) {
+	t.Parallel()
+
+	s := newTestBitSet(256, 63, 64, 127, 128)
+	var got []int
+	s.Range(func(i int) bool {
+		got = append(got, i)
+		return i < 64
+	})
+	if want := []int{63, 64}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestBitSetBounds(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{0, 1, 63, 64, 100} {
+		for _, tc := range []struct {
+			Name  string
+			Index int
+		}{
+			{Name: "size-1", Index: size - 1},
+			{Name: "size", Index: size},
+			{Name: "size+64", Index: size + 64},
+		} {
+			t.Run(fmt.Sprintf("%d %s", size, tc.Name), func(t *testing.T) {
+				t.Parallel()
+
+				i := tc.Index
+				s := NewBitSet(size)
+				if s.Contains(i) {
+					t.Fatalf("expected %d to be absent", i)
+				}
+				if s.Remove(i) {
+					t.Fatalf("expected removing absent %d to report false", i)
+				}
+				if i < 0 {
+					if s.Insert(i) || s.Set(i, true) || s.Contains(i) || s.Size() != 0 {
+						t.Fatalf("expected negative index %d to never be a member", i)
+					}
+					return
+				}
+				if !s.Insert(i) || !s.Contains(i) || s.Size() != 1 {
+					t.Fatalf("expected inserting %d to grow the set", i)
+				}
+				if s.Insert(i) || s.Size() != 1 {
+					t.Fatalf("expected inserting %d twice to report false", i)
+				}
+				if !reflect.DeepEqual(s.Slice(), []int{i}) {
+					t.Fatalf("expected [%d], got %v", i, s.Slice())
+				}
+				if !s.Set(i, false) || s.Contains(i) || s.Size() != 0 {
+					t.Fatalf("expected %d to be removed", i)
+				}
+				if !s.Set(i+64, true) || !s.Contains(i+64) || s.Contains(i) {
+					t.Fatalf("expected setting %d to grow the set", i+64)
+				}
+			})
+		}
+	}
+}
+
+func TestBitSetForEach(t *testing.T) {
+	t.Parallel()
+
+	// inserted out of order so that iteration order is not insertion order
+	s := newTestBitSet(200, 199, 5, 64, 0, 130, 63)
+	want := []int{0, 5, 63, 64, 130, 199}
+	var got []int
+	s.ForEach(func(i int) {
+		got = append(got, i)
+	})
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ForEach: expected %v, got %v", want, got)
+	}
+	if !reflect.DeepEqual(s.Slice(), want) {
+		t.Fatalf("Slice: expected %v, got %v", want, s.Slice())
+	}
+	c := s.Clone()
+	c.Remove(5)
+	if !s.Contains(5) || s.Size() != len(want) || c.Size() != len(want)-1 {
+		t.Fatal("expected a clone to be independent of the original")
+	}
+}
+
+func TestBitSetOps(t *testing.T) {
+	t.Parallel()
+
+	a := newTestBitSet(128, 0, 1, 63, 64, 100)
+	b := newTestBitSet(256, 1, 64, 101, 200)
+
+	for _, tc := range []struct {
+		Name string
+		Op   func() *BitSet
+		Want []int
+	}{
+		{Name: "union", Op: func() *BitSet { return a.Union(b) }, Want: []int{0, 1, 63, 64, 100, 101, 200}},
+		{Name: "union reversed", Op: func() *BitSet { return b.Union(a) }, Want: []int{0, 1, 63, 64, 100, 101, 200}},
+		{Name: "intersect", Op: func() *BitSet { return a.Intersect(b) }, Want: []int{1, 64}},
+		{Name: "intersect reversed", Op: func() *BitSet { return b.Intersect(a) }, Want: []int{1, 64}},
+		{Name: "difference", Op: func() *BitSet { return a.Difference(b) }, Want: []int{0, 63, 100}},
+		{Name: "difference reversed", Op: func() *BitSet { return b.Difference(a) }, Want: []int{101, 200}},
+		{Name: "intersect empty", Op: func() *BitSet { return a.Intersect(NewBitSet(0)) }},
+		{Name: "difference self", Op: func() *BitSet { return a.Difference(a) }},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			res := tc.Op()
+			if res.Size() != len(tc.Want) {
+				t.Fatalf("expected size %d, got %d", len(tc.Want), res.Size())
+			}
+			if got := res.Slice(); len(tc.Want) != 0 && !reflect.DeepEqual(got, tc.Want) || len(tc.Want) == 0 && len(got) != 0 {
+				t.Fatalf("expected %v, got %v", tc.Want, got)
+			}
+			if a.Size() != 5 || b.Size() != 4 {
+				t.Fatal("expected the operands to be unchanged")
+			}
+		})
+	}
+}
+
+// BenchmarkApplyPattern applies one more guess to universes narrowed by the
+// guesses before it, so the cost follows the number of candidates left
+func BenchmarkApplyPattern(b *testing.B) {
+	answers, _, err := LoadEmbeddedWordList(LoadOpts{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	target := mustWords(b, "shake")[0]
+	universe := NewUniverse(answers)
+	for _, guess := range mustWords(b, "trace", "sound", "whelk") {
+		pattern := target.ComputePattern(guess)
+		b.Run(fmt.Sprintf("candidates %d", universe.Count()), func(b *testing.B) {
+			for range b.N {
+				universe.ApplyPattern(pattern)
+			}
+		})
+		universe = universe.ApplyPattern(pattern)
+	}
+}
@@ -0,0 +1,161 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+var (
+	ErrClueUnknown = errors.New("Unknown clue type")
+	ErrClueArg     = errors.New("Invalid clue argument")
+)
+
+type (
+	// Clue is a starting constraint published alongside a puzzle
+	Clue struct {
+		kind    string
+		letters []uint32
+	}
+)
+
+const (
+	ClueStartsWith   = "startswith"
+	ClueDoubleLetter = "doubleletter"
+	ClueContains     = "contains"
+	ClueAnagramOf    = "anagram-of"
+)
+
+var clueKinds = []string{ClueStartsWith, ClueDoubleLetter, ClueContains, ClueAnagramOf}
+
+func ParseClue(s string) (Clue, error) {
+	kind, arg, hasArg := strings.Cut(strings.TrimSpace(s), ":")
+	kind = strings.ToLower(kind)
+	var letters []uint32
+	if hasArg {
+		for _, i := range strings.ToUpper(arg) {
+			if i < 'A' || i > 'Z' {
+				return Clue{}, fmt.Errorf("%w for %s: %q", ErrClueArg, kind, arg)
+			}
+			letters = append(letters, 1<<(i-'A'))
+		}
+	}
+	switch kind {
+	case ClueDoubleLetter:
+		if hasArg {
+			return Clue{}, fmt.Errorf("%w: %s takes no argument", ErrClueArg, kind)
+		}
+	case ClueStartsWith:
+		if len(letters) == 0 || len(letters) > len(WordleWord{}) {
+			return Clue{}, fmt.Errorf("%w: %s requires 1 to %d letters", ErrClueArg, kind, len(WordleWord{}))
+		}
+	case ClueContains, ClueAnagramOf:
+		if len(letters) == 0 {
+			return Clue{}, fmt.Errorf("%w: %s requires letters", ErrClueArg, kind)
+		}
+	default:
+		if suggestion := closestClueKind(kind); suggestion != "" {
+			return Clue{}, fmt.Errorf("%w %q, did you mean %q?", ErrClueUnknown, kind, suggestion)
+		}
+		return Clue{}, fmt.Errorf("%w %q, expected one of %s", ErrClueUnknown, kind, strings.Join(clueKinds, ", "))
+	}
+	return Clue{
+		kind:    kind,
+		letters: letters,
+	}, nil
+}
+
+func (c Clue) String() string {
+	if len(c.letters) == 0 {
+		return c.kind
+	}
+	var b strings.Builder
+	b.WriteString(c.kind)
+	b.WriteByte(':')
+	for _, v := range c.letters {
+		b.WriteByte(byte(bits.TrailingZeros32(v)) + 'A')
+	}
+	return b.String()
+}
+
+// Apply narrows a universe to the words satisfying the clue
+func (c Clue) Apply(u Universe) Universe {
+	switch c.kind {
+	case ClueStartsWith:
+		for i, v := range c.letters {
+			u = u.WithPosition(i, v, true)
+		}
+	case ClueDoubleLetter:
+		u = u.WithFilter(hasRepeatedLetter)
+	case ClueContains:
+		for _, v := range c.letters {
+			u = u.WithLetter(v, true)
+		}
+	case ClueAnagramOf:
+		var charset uint32
+		var counts [26]int
+		for _, v := range c.letters {
+			charset |= v
+			counts[bits.TrailingZeros32(v)]++
+		}
+		u = u.WithLetter(allBits&^charset, false)
+		u = u.WithFilter(func(w WordleWord) bool {
+			// each letter may be used at most as many times as it appears in
+			// the anagram source
+			remaining := counts
+			for _, v := range w[:w.Len()] {
+				k := bits.TrailingZeros32(v)
+				if remaining[k] == 0 {
+					return false
+				}
+				remaining[k]--
+			}
+			return true
+		})
+	}
+	return u
+}
+
+func hasRepeatedLetter(w WordleWord) bool {
+	var seen uint32
+	for _, v := range w {
+		if seen&v != 0 {
+			return true
+		}
+		seen |= v
+	}
+	return false
+}
+
+func closestClueKind(kind string) string {
+	best := ""
+	bestDist := 3
+	for _, v := range clueKinds {
+		if d := editDistance(kind, v); d < bestDist {
+			best = v
+			bestDist = d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
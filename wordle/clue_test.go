@@ -0,0 +1,97 @@
+package wordle
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClueApply(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "crane", "geese", "stool", "slate", "silts", "tiles", "islet", "lines")
+	slices.SortFunc(words, WordleWord.Compare)
+	universe := NewUniverse(words)
+
+	for _, tc := range []struct {
+		Clues    []string
+		Expected []string
+	}{
+		{Clues: []string{"startswith:S"}, Expected: []string{"SILTS", "SLATE", "STOOL"}},
+		{Clues: []string{"startswith:st"}, Expected: []string{"STOOL"}},
+		{Clues: []string{"doubleletter"}, Expected: []string{"GEESE", "SILTS", "STOOL"}},
+		{Clues: []string{"contains:TL"}, Expected: []string{"ISLET", "SILTS", "SLATE", "STOOL", "TILES"}},
+		{Clues: []string{"contains:OU"}, Expected: nil},
+		{Clues: []string{"anagram-of:LISTEN"}, Expected: []string{"ISLET", "LINES", "TILES"}},
+		{Clues: []string{"anagram-of:SILTS"}, Expected: []string{"SILTS"}},
+		{Clues: []string{"startswith:S", "doubleletter"}, Expected: []string{"SILTS", "STOOL"}},
+		{Clues: []string{"doubleletter", "anagram-of:LISTEN"}, Expected: nil},
+		{Clues: []string{"anagram-of:TOOLS", "doubleletter"}, Expected: []string{"STOOL"}},
+		{Clues: []string{"contains:E", "anagram-of:LISTEN", "startswith:L"}, Expected: []string{"LINES"}},
+	} {
+		t.Run(strings.Join(tc.Clues, " "), func(t *testing.T) {
+			t.Parallel()
+
+			u := universe
+			for _, v := range tc.Clues {
+				clue, err := ParseClue(v)
+				if err != nil {
+					t.Fatal(err)
+				}
+				u = clue.Apply(u)
+			}
+			var got []string
+			for _, v := range u.Candidates() {
+				got = append(got, v.String())
+			}
+			if !slices.Equal(got, tc.Expected) {
+				t.Fatalf("expected %v, got %v", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestParseClue(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Clue   string
+		String string
+		Err    error
+		Msg    string
+	}{
+		{Clue: "startswith:s", String: "startswith:S"},
+		{Clue: " Contains:OU ", String: "contains:OU"},
+		{Clue: "doubleletter", String: "doubleletter"},
+		{Clue: "anagram-of:listen", String: "anagram-of:LISTEN"},
+		{Clue: "startwith:S", Err: ErrClueUnknown, Msg: `did you mean "startswith"`},
+		{Clue: "anagram:LISTEN", Err: ErrClueUnknown, Msg: "expected one of"},
+		{Clue: "doubleletter:E", Err: ErrClueArg},
+		{Clue: "contains:", Err: ErrClueArg},
+		{Clue: "startswith", Err: ErrClueArg},
+		{Clue: "startswith:S1", Err: ErrClueArg},
+		{Clue: "anagram-of:é", Err: ErrClueArg},
+	} {
+		t.Run(tc.Clue, func(t *testing.T) {
+			t.Parallel()
+
+			clue, err := ParseClue(tc.Clue)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), tc.Msg) {
+					t.Fatalf("expected error containing %q, got %v", tc.Msg, err)
+				}
+				return
+			}
+			if s := clue.String(); s != tc.String {
+				t.Fatalf("expected %s, got %s", tc.String, s)
+			}
+			if reparsed, err := ParseClue(clue.String()); err != nil || reparsed.String() != tc.String {
+				t.Fatalf("expected %s to parse back, got %v %v", tc.String, reparsed, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package wordle
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/xorkevin/wordlebot/internal/wordlistcheck"
+)
+
+//go:generate go run ../internal/genwordlist -o wordlist_gen.go -pkg wordle wordlist.json
+//go:generate go run ../internal/genwordlist -o guesslist_gen.go -pkg wordle -prefix embeddedGuesslist guesslist.json
+
+var (
+	//go:embed wordlist.json
+	wordlist []byte
+	//go:embed guesslist.json
+	guesslist []byte
+)
+
+// DefaultWords returns the embedded answer list
+func DefaultWords() ([]WordleWord, error) {
+	words, _, err := LoadEmbeddedWordList(LoadOpts{
+		StripAnnotations: DefaultStripAnnotations,
+	})
+	return words, err
+}
+
+// DefaultGuesses returns the embedded allowed guesses that are not answers
+func DefaultGuesses() ([]WordleWord, error) {
+	words, _, err := LoadEmbeddedGuessList(LoadOpts{
+		StripAnnotations: DefaultStripAnnotations,
+	})
+	return words, err
+}
+
+func LoadEmbeddedWordList(opts LoadOpts) ([]WordleWord, LoadReport, error) {
+	return loadEmbedded("wordlist", wordlist, embeddedWordlistCount, embeddedWordlistChecksum, opts)
+}
+
+func LoadEmbeddedGuessList(opts LoadOpts) ([]WordleWord, LoadReport, error) {
+	return loadEmbedded("guesslist", guesslist, embeddedGuesslistCount, embeddedGuesslistChecksum, opts)
+}
+
+func loadEmbedded(name string, data []byte, count int, checksum string, opts LoadOpts) ([]WordleWord, LoadReport, error) {
+	if err := wordlistcheck.Verify(data, count, checksum); err != nil {
+		return nil, LoadReport{}, fmt.Errorf("Invalid embedded %s: %w", name, err)
+	}
+	var entries []string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, LoadReport{}, fmt.Errorf("Invalid embedded %s: %w", name, err)
+	}
+	words, report := LoadWordList(entries, opts)
+	return words, report, nil
+}
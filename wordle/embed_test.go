@@ -0,0 +1,40 @@
+package wordle
+
+import (
+	"testing"
+
+	"github.com/xorkevin/wordlebot/internal/wordlistcheck"
+)
+
+func TestEmbeddedLists(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name     string
+		Data     []byte
+		Count    int
+		Checksum string
+	}{
+		{Name: "wordlist", Data: wordlist, Count: embeddedWordlistCount, Checksum: embeddedWordlistChecksum},
+		{Name: "guesslist", Data: guesslist, Count: embeddedGuesslistCount, Checksum: embeddedGuesslistChecksum},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := wordlistcheck.Verify(tc.Data, tc.Count, tc.Checksum); err != nil {
+				t.Fatalf("embedded list does not match its generated metadata: %v", err)
+			}
+			words, report, err := loadEmbedded(tc.Name, tc.Data, tc.Count, tc.Checksum, LoadOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(words) != tc.Count || len(report.Rejected) != 0 {
+				t.Fatalf("expected %d words, got %d with %d rejected", tc.Count, len(words), len(report.Rejected))
+			}
+		})
+	}
+
+	if _, _, err := loadEmbedded("wordlist", []byte(`["cigar","r3but"]`), embeddedWordlistCount, embeddedWordlistChecksum, LoadOpts{}); err == nil {
+		t.Fatal("expected a corrupt embed to fail")
+	}
+}
@@ -0,0 +1,164 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrNoCandidates = errors.New("Error no candidates remain")
+
+type (
+	// FeedbackProvider supplies the pattern received for a guess, whether it is
+	// computed from a known target or obtained from elsewhere
+	FeedbackProvider interface {
+		Feedback(guess WordleWord) (WordlePattern, error)
+	}
+
+	// LocalTarget computes feedback from a known target
+	LocalTarget struct {
+		Target WordleWord
+	}
+
+	// InteractiveHuman asks a person for the feedback they received from a
+	// live puzzle
+	InteractiveHuman struct {
+		w        io.Writer
+		readLine func() (string, error)
+	}
+
+	// RemoteHTTP fetches feedback from a url template with a {guess}
+	// placeholder whose response body is a pattern such as BYGBB
+	RemoteHTTP struct {
+		client      *http.Client
+		urlTemplate string
+	}
+
+	// Adversarial commits to no target and answers each guess with the
+	// feedback that keeps the most candidates alive
+	Adversarial struct {
+		universe Universe
+		history  []Universe
+	}
+)
+
+func NewLocalTarget(target WordleWord) *LocalTarget {
+	return &LocalTarget{
+		Target: target,
+	}
+}
+
+func (p *LocalTarget) Feedback(guess WordleWord) (WordlePattern, error) {
+	return p.Target.ComputePattern(guess), nil
+}
+
+// NewInteractiveHuman prompts on w and reads feedback with readLine, which
+// allows the caller to share its input reader
+func NewInteractiveHuman(w io.Writer, readLine func() (string, error)) *InteractiveHuman {
+	return &InteractiveHuman{
+		w:        w,
+		readLine: readLine,
+	}
+}
+
+func (p *InteractiveHuman) Feedback(guess WordleWord) (WordlePattern, error) {
+	fmt.Fprint(p.w, "Pattern: ")
+	line, err := p.readLine()
+	if err != nil {
+		return WordlePattern{}, err
+	}
+	return ParseWordlePattern(guess, strings.TrimSpace(line))
+}
+
+const (
+	remoteFeedbackTimeout = 3 * time.Second
+	maxRemoteFeedbackLen  = 256
+)
+
+func NewRemoteHTTP(urlTemplate string) *RemoteHTTP {
+	return &RemoteHTTP{
+		client: &http.Client{
+			Timeout: remoteFeedbackTimeout,
+		},
+		urlTemplate: urlTemplate,
+	}
+}
+
+func (p *RemoteHTTP) Feedback(guess WordleWord) (WordlePattern, error) {
+	u := strings.ReplaceAll(p.urlTemplate, "{guess}", url.PathEscape(strings.ToLower(guess.String())))
+	res, err := p.client.Get(u)
+	if err != nil {
+		return WordlePattern{}, fmt.Errorf("Failed fetching feedback: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WordlePattern{}, fmt.Errorf("Failed fetching feedback: status %d", res.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxRemoteFeedbackLen))
+	if err != nil {
+		return WordlePattern{}, fmt.Errorf("Failed reading feedback: %w", err)
+	}
+	return ParseWordlePattern(guess, strings.TrimSpace(string(body)))
+}
+
+func NewAdversarial(universe Universe) *Adversarial {
+	return &Adversarial{
+		universe: universe,
+	}
+}
+
+func (p *Adversarial) Feedback(guess WordleWord) (WordlePattern, error) {
+	pattern, bucket := ChooseAdversarialPattern(guess, p.universe.Candidates())
+	if len(bucket) == 0 {
+		return WordlePattern{}, ErrNoCandidates
+	}
+	p.history = append(p.history, p.universe)
+	p.universe = p.universe.ApplyPattern(pattern)
+	return pattern, nil
+}
+
+// ChooseAdversarialPattern partitions candidates by the pattern they give
+// guess, and returns the pattern of the largest partition along with its
+// candidates. Ties go to the lowest pattern code, which is never the all
+// green pattern, so a guess is only confirmed once it is the last candidate.
+func ChooseAdversarialPattern(guess WordleWord, candidates []WordleWord) (WordlePattern, []WordleWord) {
+	g := toLetterWord(guess)
+	buckets := make([]int, PatternCount(g.n))
+	representative := make([]WordleWord, len(buckets))
+	for _, v := range candidates {
+		code := patternCode(toLetterWord(v), g)
+		if buckets[code] == 0 {
+			representative[code] = v
+		}
+		buckets[code]++
+	}
+	best := -1
+	for code, count := range buckets {
+		if count > 0 && (best < 0 || count > buckets[best]) {
+			best = code
+		}
+	}
+	if best < 0 {
+		return WordlePattern{}, nil
+	}
+	bucket := make([]WordleWord, 0, buckets[best])
+	for _, v := range candidates {
+		if patternCode(toLetterWord(v), g) == best {
+			bucket = append(bucket, v)
+		}
+	}
+	return representative[best].ComputePattern(guess), bucket
+}
+
+// Undo reverts the universe to before the last feedback
+func (p *Adversarial) Undo() {
+	if len(p.history) == 0 {
+		return
+	}
+	p.universe = p.history[len(p.history)-1]
+	p.history = p.history[:len(p.history)-1]
+}
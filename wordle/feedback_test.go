@@ -0,0 +1,81 @@
+package wordle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChooseAdversarialPattern(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name       string
+		Guess      string
+		Candidates []string
+		Pattern    string
+		Bucket     []string
+	}{
+		{
+			// BGGGG holds bakes, makes, and takes
+			Name:       "largest bucket",
+			Guess:      "cakes",
+			Candidates: []string{"bakes", "cakes", "makes", "takes"},
+			Pattern:    "BGGGG",
+			Bucket:     []string{"bakes", "makes", "takes"},
+		},
+		{
+			// GGGGG and BGGGG both hold one word
+			Name:       "tie avoids all green",
+			Guess:      "cakes",
+			Candidates: []string{"cakes", "bakes"},
+			Pattern:    "BGGGG",
+			Bucket:     []string{"bakes"},
+		},
+		{
+			Name:       "last candidate",
+			Guess:      "cakes",
+			Candidates: []string{"cakes"},
+			Pattern:    "GGGGG",
+			Bucket:     []string{"cakes"},
+		},
+		{
+			// eerie BYBBG, geese BBBBG, label BBYBY, and llama BBGBB all hold
+			// one word, and BBGBB has the lowest code
+			Name:       "tie goes to lowest code",
+			Guess:      "crane",
+			Candidates: []string{"eerie", "geese", "label", "llama"},
+			Pattern:    "BBGBB",
+			Bucket:     []string{"llama"},
+		},
+		{
+			// crane gives BYBBG for eerie and serve, and BBBBG for geese
+			Name:       "repeated letters",
+			Guess:      "crane",
+			Candidates: []string{"eerie", "geese", "serve"},
+			Pattern:    "BYBBG",
+			Bucket:     []string{"eerie", "serve"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			guess := mustWords(t, tc.Guess)[0]
+			pattern, bucket := ChooseAdversarialPattern(guess, mustWords(t, tc.Candidates...))
+			if got := pattern.Compact(); got != tc.Pattern {
+				t.Fatalf("expected pattern %s, got %s", tc.Pattern, got)
+			}
+			if want := mustWords(t, tc.Bucket...); !reflect.DeepEqual(bucket, want) {
+				t.Fatalf("expected bucket %v, got %v", want, bucket)
+			}
+		})
+	}
+
+	t.Run("no candidates", func(t *testing.T) {
+		t.Parallel()
+
+		pattern, bucket := ChooseAdversarialPattern(mustWords(t, "cakes")[0], nil)
+		if pattern.Len() != 0 || bucket != nil {
+			t.Fatalf("expected no pattern, got %s %v", pattern.Compact(), bucket)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package wordle
+
+import (
+	"math/bits"
+)
+
+type (
+	// LetterIndex holds the sets of words with each letter at each position,
+	// and the sets of words containing each letter, so that the constraints
+	// of a pattern are applied by intersecting sets rather than by checking
+	// every word
+	LetterIndex struct {
+		words []WordleWord
+		at    [MaxWordLen][26]*BitSet
+		has   [26]*BitSet
+	}
+)
+
+func NewLetterIndex(words []WordleWord) *LetterIndex {
+	x := &LetterIndex{
+		words: words,
+	}
+	for i := range x.at {
+		for c := range x.at[i] {
+			x.at[i][c] = NewBitSet(len(words))
+		}
+	}
+	for c := range x.has {
+		x.has[c] = NewBitSet(len(words))
+	}
+	for n, v := range x.words {
+		for i, c := range v[:v.Len()] {
+			k := bits.TrailingZeros32(c)
+			x.at[i][k].Insert(n)
+			x.has[k].Insert(n)
+		}
+	}
+	return x
+}
+
+// Filter returns the indices into the words of the universe which remain
+// after patterns, where the universe must be built from the words of the
+// index. Only the positions and presence of letters are checked, and not
+// how many times a letter occurs, so the result may include words that the
+// universe would eliminate, but never excludes one that it keeps.
+func (x *LetterIndex) Filter(u Universe, patterns []WordlePattern) *BitSet {
+	s := u.candidates.Clone()
+	for _, p := range patterns {
+		present := p.PresentChars()
+		for i, v := range p[:p.Len()] {
+			c := bits.TrailingZeros32(v.v)
+			switch v.kind {
+			case PatternKindG:
+				s = s.Intersect(x.at[i][c])
+			case PatternKindY:
+				s = s.Intersect(x.has[c]).Difference(x.at[i][c])
+			default:
+				// a black copy of a letter marked elsewhere in the guess only
+				// rules out this position
+				if present&v.v != 0 {
+					s = s.Difference(x.at[i][c])
+				} else {
+					s = s.Difference(x.has[c])
+				}
+			}
+		}
+	}
+	return s
+}
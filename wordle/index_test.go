@@ -0,0 +1,119 @@
+package wordle
+
+import (
+	"testing"
+)
+
+func TestLetterIndexFilter(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "abide", "bakes", "cakes", "crane", "eerie", "geese", "label", "llama", "sassy", "speed", "stool")
+	universe := NewUniverse(words)
+	index := NewLetterIndex(words)
+
+	for _, tc := range []struct {
+		Name    string
+		Target  string
+		Guesses []string
+		Want    []string
+	}{
+		{Name: "no history", Target: "crane", Want: []string{"abide", "bakes", "cakes", "crane", "eerie", "geese", "label", "llama", "sassy", "speed", "stool"}},
+		{Name: "greens", Target: "cakes", Guesses: []string{"bakes"}, Want: []string{"cakes"}},
+		{Name: "yellows", Target: "crane", Guesses: []string{"eerie"}, Want: []string{"crane"}},
+		{
+			// eerie gets YYBBB, so speed has at least two Es. The index only
+			// knows that there is an E outside of the first, second, and last
+			// positions.
+			Name:    "repeated letters",
+			Target:  "speed",
+			Guesses: []string{"eerie"},
+			Want:    []string{"bakes", "cakes", "label", "speed"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			target := mustWords(t, tc.Target)[0]
+			var patterns []WordlePattern
+			for _, v := range mustWords(t, tc.Guesses...) {
+				patterns = append(patterns, target.ComputePattern(v))
+			}
+			var got []string
+			index.Filter(universe, patterns).ForEach(func(i int) {
+				got = append(got, words[i].String())
+			})
+			want := make([]string, 0, len(tc.Want))
+			for _, v := range mustWords(t, tc.Want...) {
+				want = append(want, v.String())
+			}
+			if len(got) != len(want) {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+			for n := range want {
+				if got[n] != want[n] {
+					t.Fatalf("expected %v, got %v", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestLetterIndexBound(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "abide", "abbey", "babes", "crane", "eerie", "geese", "label", "llama", "sassy", "speed", "stool")
+	universe := NewUniverse(words)
+	index := NewLetterIndex(words)
+	for _, target := range words {
+		for _, first := range words {
+			for _, second := range words {
+				patterns := []WordlePattern{target.ComputePattern(first), target.ComputePattern(second)}
+				exact := universe.ApplyPattern(patterns[0]).ApplyPattern(patterns[1])
+				approx := index.Filter(universe, patterns)
+				// the index never drops a word that the universe keeps
+				for _, i := range exact.CandidateIndices() {
+					if !approx.Contains(i) {
+						t.Fatalf("target %s guesses %s %s: index dropped %s", target, first, second, words[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+func benchHistory(b *testing.B) (Universe, []WordlePattern) {
+	b.Helper()
+	answers, _, err := LoadEmbeddedWordList(LoadOpts{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	target := mustWords(b, "shake")[0]
+	var patterns []WordlePattern
+	for _, v := range mustWords(b, "mound", "lucky", "prick", "trace", "beach", "snake") {
+		patterns = append(patterns, target.ComputePattern(v))
+	}
+	return NewUniverse(answers), patterns
+}
+
+// BenchmarkLetterIndexFilter and BenchmarkUniverseFilter apply the same six
+// turn history to the full answer list
+func BenchmarkLetterIndexFilter(b *testing.B) {
+	universe, patterns := benchHistory(b)
+	index := NewLetterIndex(universe.Words())
+	b.ResetTimer()
+	for range b.N {
+		index.Filter(universe, patterns).Size()
+	}
+}
+
+func BenchmarkUniverseFilter(b *testing.B) {
+	universe, patterns := benchHistory(b)
+	b.ResetTimer()
+	for range b.N {
+		u := universe
+		for _, v := range patterns {
+			u = u.ApplyPattern(v)
+		}
+		u.Count()
+	}
+}
@@ -0,0 +1,116 @@
+package wordle
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"strings"
+)
+
+var ErrKeyboardLayout = errors.New("Error keyboard layout")
+
+type (
+	// KeyboardLayout records which keys are physically adjacent, where each
+	// row is offset half a key to the right of the row above it
+	KeyboardLayout struct {
+		adjacent [26]uint32
+	}
+)
+
+var qwertyRows = []string{
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+}
+
+func QWERTYLayout() *KeyboardLayout {
+	k, _ := NewKeyboardLayout(qwertyRows)
+	return k
+}
+
+// NewKeyboardLayout builds a layout from rows of letters from top to bottom.
+// A key is adjacent to its neighbors in its row, to the keys above it and
+// above to the right, and to the keys below it and below to the left.
+func NewKeyboardLayout(rows []string) (*KeyboardLayout, error) {
+	var seen uint32
+	for _, row := range rows {
+		for _, c := range row {
+			if c < 'a' || c > 'z' {
+				return nil, fmt.Errorf("%w: invalid key %q", ErrKeyboardLayout, c)
+			}
+			if seen&(1<<(c-'a')) != 0 {
+				return nil, fmt.Errorf("%w: repeated key %q", ErrKeyboardLayout, c)
+			}
+			seen |= 1 << (c - 'a')
+		}
+	}
+	k := &KeyboardLayout{}
+	link := func(r, i, s, j int) {
+		if s < 0 || s >= len(rows) || i < 0 || i >= len(rows[r]) || j < 0 || j >= len(rows[s]) {
+			return
+		}
+		a, b := rows[r][i]-'a', rows[s][j]-'a'
+		k.adjacent[a] |= 1 << b
+		k.adjacent[b] |= 1 << a
+	}
+	for r, row := range rows {
+		for i := range row {
+			link(r, i, r, i+1)
+			link(r, i, r+1, i-1)
+			link(r, i, r+1, i)
+		}
+	}
+	return k, nil
+}
+
+// ParseKeyboardLayout reads a layout file with one row of keys per line, such
+// as qwertyuiop, ignoring blank lines and lines starting with #
+func ParseKeyboardLayout(r io.Reader) (*KeyboardLayout, error) {
+	var rows []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rows = append(rows, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows", ErrKeyboardLayout)
+	}
+	return NewKeyboardLayout(rows)
+}
+
+// Adjacent reports whether the keys of letters a and b are next to each
+// other, where letters are single bit masks as in WordleWord
+func (k *KeyboardLayout) Adjacent(a, b uint32) bool {
+	return k.adjacent[bits.TrailingZeros32(a)]&b != 0
+}
+
+// AdjacentTypos returns the words which differ from w in exactly one letter
+// whose key is adjacent to the one typed
+func (k *KeyboardLayout) AdjacentTypos(w WordleWord, words []WordleWord) []WordleWord {
+	var typos []WordleWord
+	for _, v := range words {
+		diff := -1
+		for i := range w {
+			if w[i] == v[i] {
+				continue
+			}
+			if diff >= 0 {
+				diff = -2
+				break
+			}
+			diff = i
+		}
+		if diff >= 0 && w[diff] != 0 && v[diff] != 0 && k.Adjacent(w[diff], v[diff]) {
+			typos = append(typos, v)
+		}
+	}
+	return typos
+}
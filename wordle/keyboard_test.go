@@ -0,0 +1,126 @@
+package wordle
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeyboardAdjacent(t *testing.T) {
+	t.Parallel()
+
+	k := QWERTYLayout()
+	for _, tc := range []struct {
+		A, B     byte
+		Adjacent bool
+	}{
+		{A: 'q', B: 'w', Adjacent: true},
+		{A: 'q', B: 'a', Adjacent: true},
+		{A: 'w', B: 'a', Adjacent: true},
+		{A: 'e', B: 'd', Adjacent: true},
+		{A: 'b', B: 'n', Adjacent: true},
+		{A: 'a', B: 'z', Adjacent: true},
+		{A: 'p', B: 'l', Adjacent: true},
+		{A: 'k', B: 'm', Adjacent: true},
+		{A: 'p', B: 'a', Adjacent: false},
+		{A: 'l', B: 'z', Adjacent: false},
+		{A: 'q', B: 'z', Adjacent: false},
+		{A: 'q', B: 's', Adjacent: false},
+		{A: 'l', B: 'm', Adjacent: false},
+		{A: 'm', B: 'q', Adjacent: false},
+		{A: 'a', B: 'a', Adjacent: false},
+	} {
+		t.Run(string([]byte{tc.A, tc.B}), func(t *testing.T) {
+			t.Parallel()
+
+			a, b := uint32(1)<<(tc.A-'a'), uint32(1)<<(tc.B-'a')
+			if got := k.Adjacent(a, b); got != tc.Adjacent {
+				t.Fatalf("expected %t, got %t", tc.Adjacent, got)
+			}
+			if got := k.Adjacent(b, a); got != tc.Adjacent {
+				t.Fatalf("expected %t reversed, got %t", tc.Adjacent, got)
+			}
+		})
+	}
+}
+
+func TestKeyboardAdjacentTypos(t *testing.T) {
+	t.Parallel()
+
+	k := QWERTYLayout()
+	words := mustWords(t, "crane", "crate", "plank", "plans", "aloft", "zloft")
+	for _, tc := range []struct {
+		Word  string
+		Typos []string
+	}{
+		{Word: "crabe", Typos: []string{"crane"}},
+		{Word: "crare", Typos: []string{"crate"}},
+		{Word: "crane"},
+		{Word: "crbne"},
+		{Word: "brabe"},
+		{Word: "olank", Typos: []string{"plank"}},
+		{Word: "plana", Typos: []string{"plans"}},
+		{Word: "sloft", Typos: []string{"aloft", "zloft"}},
+		{Word: "xloft", Typos: []string{"zloft"}},
+		{Word: "qloft", Typos: []string{"aloft"}},
+		{Word: "ploft"},
+		{Word: "mloft"},
+		{Word: "cran"},
+	} {
+		t.Run(tc.Word, func(t *testing.T) {
+			t.Parallel()
+
+			got := k.AdjacentTypos(mustWords(t, tc.Word)[0], words)
+			var want []WordleWord
+			if len(tc.Typos) != 0 {
+				want = mustWords(t, tc.Typos...)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestParseKeyboardLayout(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name     string
+		Layout   string
+		Adjacent string
+		Err      bool
+	}{
+		{Name: "qwerty", Layout: "qwertyuiop\nasdfghjkl\nzxcvbnm\n", Adjacent: "qa"},
+		{Name: "comments and blanks", Layout: "# dvorak\n\npyfgcrl\n  aoeuidhtns  \n\nqjkxbmwvz\n", Adjacent: "pa"},
+		{Name: "upper case", Layout: "QWE\nASD\n", Adjacent: "ws"},
+		{Name: "partial", Layout: "ab\n", Adjacent: "ab"},
+		{Name: "empty", Layout: "", Err: true},
+		{Name: "only comments", Layout: "# nothing\n\n", Err: true},
+		{Name: "repeated key in a row", Layout: "qwertyq\n", Err: true},
+		{Name: "repeated key across rows", Layout: "qwe\nasq\n", Err: true},
+		{Name: "digit", Layout: "qwe1\n", Err: true},
+		{Name: "inner space", Layout: "qw e\n", Err: true},
+		{Name: "accented", Layout: "qwé\n", Err: true},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			k, err := ParseKeyboardLayout(strings.NewReader(tc.Layout))
+			if tc.Err {
+				if !errors.Is(err, ErrKeyboardLayout) {
+					t.Fatalf("expected a keyboard layout error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			a, b := uint32(1)<<(tc.Adjacent[0]-'a'), uint32(1)<<(tc.Adjacent[1]-'a')
+			if !k.Adjacent(a, b) {
+				t.Fatalf("expected %c and %c to be adjacent", tc.Adjacent[0], tc.Adjacent[1])
+			}
+		})
+	}
+}
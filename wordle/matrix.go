@@ -0,0 +1,287 @@
+package wordle
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrStaleMatrix   = errors.New("Error pattern matrix does not match the word lists")
+	ErrCorruptMatrix = errors.New("Error pattern matrix is corrupt")
+)
+
+const (
+	// MaxMatrixWordLen is the longest word whose patterns fit in a byte
+	MaxMatrixWordLen = 5
+
+	matrixMagic   = "WBPM"
+	matrixVersion = 2
+	// matrixShards is the number of equal spans of the encoded patterns that
+	// are each checksummed in the header
+	matrixShards = 64
+)
+
+type (
+	// matrixHeader precedes the encoded patterns of a serialized matrix, and
+	// records the word list hashes so that a matrix built from other lists is
+	// rejected, and the checksums of each shard of the patterns so that a
+	// corrupted matrix is detected
+	matrixHeader struct {
+		Magic       [4]byte
+		Version     uint32
+		GuessesHash [64]byte
+		AnswersHash [64]byte
+		Guesses     uint32
+		Answers     uint32
+		ShardCRCs   [matrixShards]uint32
+	}
+)
+
+type (
+	// PatternMatrix holds the encoded pattern of every guess against every
+	// answer, so that repeated scoring is a table lookup. It takes one byte per
+	// pair, which is about 34MB for the full guess and answer lists, and so only
+	// holds words of up to 5 letters.
+	PatternMatrix struct {
+		guesses []WordleWord
+		answers []WordleWord
+		codes   []uint8
+	}
+)
+
+// EncodePattern returns the base 3 code of a pattern, which fits in a byte
+// for words of up to 5 letters
+func EncodePattern(p WordlePattern) uint8 {
+	return uint8(p.Code())
+}
+
+// BuildPatternMatrix returns the encoded patterns of every guess against every
+// answer in row major order by guess
+func BuildPatternMatrix(guesses, answers []WordleWord) []uint8 {
+	codes := make([]uint8, len(guesses)*len(answers))
+	if len(codes) == 0 {
+		return codes
+	}
+	answerLetters := toLetterWords(answers)
+	workers := runtime.GOMAXPROCS(0)
+	chunk := (len(guesses) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(guesses); start += chunk {
+		end := min(start+chunk, len(guesses))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				g := toLetterWord(guesses[i])
+				row := codes[i*len(answers) : (i+1)*len(answers)]
+				for j, v := range answerLetters {
+					row[j] = uint8(patternCode(v, g))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return codes
+}
+
+func NewPatternMatrix(guesses, answers []WordleWord) *PatternMatrix {
+	return &PatternMatrix{
+		guesses: guesses,
+		answers: answers,
+		codes:   BuildPatternMatrix(guesses, answers),
+	}
+}
+
+func (m *PatternMatrix) Guesses() []WordleWord {
+	return m.guesses
+}
+
+func (m *PatternMatrix) Answers() []WordleWord {
+	return m.answers
+}
+
+// Pattern returns the encoded pattern of guess index g against answer index a
+func (m *PatternMatrix) Pattern(g, a int) uint8 {
+	return m.codes[g*len(m.answers)+a]
+}
+
+// Row returns the encoded patterns of guess index g against every answer
+func (m *PatternMatrix) Row(g int) []uint8 {
+	return m.codes[g*len(m.answers) : (g+1)*len(m.answers)]
+}
+
+// Score scores guess index g against a subset of answer indices
+func (m *PatternMatrix) Score(g int, candidates []int) ScoredGuess {
+	return m.score(g, candidates, make([]int, PatternCount(m.guesses[g].Len())))
+}
+
+func (m *PatternMatrix) score(g int, candidates []int, buckets []int) ScoredGuess {
+	if len(candidates) == 0 {
+		return ScoredGuess{
+			Word: m.guesses[g],
+		}
+	}
+	clear(buckets)
+	row := m.Row(g)
+	for _, v := range candidates {
+		buckets[row[v]]++
+	}
+	s := scoreBuckets(buckets, len(candidates))
+	s.Word = m.guesses[g]
+	return s
+}
+
+// CandidateIndices returns the indices of the answers remaining in universe
+func (m *PatternMatrix) CandidateIndices(universe Universe) []int {
+	var candidates []int
+	for i, v := range m.answers {
+		if universe.Contains(v) {
+			candidates = append(candidates, i)
+		}
+	}
+	return candidates
+}
+
+// RankGuesses ranks every guess against a subset of answer indices, and
+// matches RankGuesses over the same guesses and candidates
+func (m *PatternMatrix) RankGuesses(candidates []int, topN int) []ScoredGuess {
+	return m.rankGuesses(candidates, topN, compareScoredGuess)
+}
+
+func (m *PatternMatrix) RankGuessesMinimax(candidates []int, topN int) []ScoredGuess {
+	return m.rankGuesses(candidates, topN, compareMinimax)
+}
+
+func (m *PatternMatrix) rankGuesses(candidates []int, topN int, compare func(a, b ScoredGuess) int) []ScoredGuess {
+	if len(candidates) == 0 {
+		return nil
+	}
+	candidateSet := make(map[WordleWord]struct{}, len(candidates))
+	for _, v := range candidates {
+		candidateSet[m.answers[v]] = struct{}{}
+	}
+	buckets := make([]int, PatternCount(m.answers[candidates[0]].Len()))
+	scores := make([]ScoredGuess, len(m.guesses))
+	for i, v := range m.guesses {
+		scores[i] = m.score(i, candidates, buckets)
+		_, scores[i].Candidate = candidateSet[v]
+	}
+	slices.SortStableFunc(scores, compare)
+	if len(scores) > topN {
+		scores = scores[:topN]
+	}
+	return scores
+}
+
+func newMatrixHeader(guesses, answers []WordleWord) matrixHeader {
+	h := matrixHeader{
+		Version: matrixVersion,
+		Guesses: uint32(len(guesses)),
+		Answers: uint32(len(answers)),
+	}
+	copy(h.Magic[:], matrixMagic)
+	copy(h.GuessesHash[:], WordsHash(guesses))
+	copy(h.AnswersHash[:], WordsHash(answers))
+	return h
+}
+
+// shardCRCs returns the checksum of each shard of codes, where every shard
+// but the last has the same length
+func shardCRCs(codes []uint8) [matrixShards]uint32 {
+	var crcs [matrixShards]uint32
+	size := (len(codes) + matrixShards - 1) / matrixShards
+	for i := range crcs {
+		start := min(i*size, len(codes))
+		end := min(start+size, len(codes))
+		crcs[i] = crc32.ChecksumIEEE(codes[start:end])
+	}
+	return crcs
+}
+
+// WriteTo serializes the matrix with a header identifying its word lists
+func (m *PatternMatrix) WriteTo(w io.Writer) (int64, error) {
+	h := newMatrixHeader(m.guesses, m.answers)
+	h.ShardCRCs = shardCRCs(m.codes)
+	b := bufio.NewWriter(w)
+	if err := binary.Write(b, binary.LittleEndian, h); err != nil {
+		return 0, err
+	}
+	if _, err := b.Write(m.codes); err != nil {
+		return 0, err
+	}
+	if err := b.Flush(); err != nil {
+		return 0, err
+	}
+	return int64(binary.Size(h) + len(m.codes)), nil
+}
+
+// ReadPatternMatrix reads a matrix serialized by WriteTo, returning
+// ErrStaleMatrix if it was built from word lists other than guesses and
+// answers, and ErrCorruptMatrix if a shard does not match its checksum
+func ReadPatternMatrix(r io.Reader, guesses, answers []WordleWord) (*PatternMatrix, error) {
+	var h matrixHeader
+	if err := binary.Read(r, binary.LittleEndian, &h); err != nil {
+		return nil, fmt.Errorf("Failed reading pattern matrix header: %w", err)
+	}
+	crcs := h.ShardCRCs
+	h.ShardCRCs = [matrixShards]uint32{}
+	if h != newMatrixHeader(guesses, answers) {
+		return nil, ErrStaleMatrix
+	}
+	codes := make([]uint8, len(guesses)*len(answers))
+	if _, err := io.ReadFull(r, codes); err != nil {
+		return nil, fmt.Errorf("Failed reading pattern matrix: %w", err)
+	}
+	for i, v := range shardCRCs(codes) {
+		if v != crcs[i] {
+			return nil, fmt.Errorf("%w: shard %d checksum mismatch", ErrCorruptMatrix, i)
+		}
+	}
+	return &PatternMatrix{
+		guesses: guesses,
+		answers: answers,
+		codes:   codes,
+	}, nil
+}
+
+// VerifySample recomputes n randomly chosen patterns of the matrix, and
+// returns ErrCorruptMatrix for the first that differs
+func (m *PatternMatrix) VerifySample(r *rand.Rand, n int) error {
+	if len(m.codes) == 0 {
+		return nil
+	}
+	for range n {
+		g, a := r.IntN(len(m.guesses)), r.IntN(len(m.answers))
+		if err := m.verify(g, a, EncodePattern(m.answers[a].ComputePattern(m.guesses[g]))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Verify recomputes every pattern of the matrix, and returns
+// ErrCorruptMatrix for the first that differs
+func (m *PatternMatrix) Verify() error {
+	codes := BuildPatternMatrix(m.guesses, m.answers)
+	for i, v := range codes {
+		if err := m.verify(i/len(m.answers), i%len(m.answers), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *PatternMatrix) verify(g, a int, code uint8) error {
+	if got := m.Pattern(g, a); got != code {
+		return fmt.Errorf("%w: guess %s answer %s has pattern %d, expected %d", ErrCorruptMatrix, m.guesses[g], m.answers[a], got, code)
+	}
+	return nil
+}
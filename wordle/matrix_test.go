@@ -0,0 +1,213 @@
+package wordle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPatternMatrix(t *testing.T) {
+	t.Parallel()
+
+	answers := mustWords(t, "bakes", "cakes", "eerie", "geese", "makes", "takes")
+	guesses := append(mustWords(t, "cflmz", "crane", "speed"), answers...)
+	m := NewPatternMatrix(guesses, answers)
+	for g, guess := range guesses {
+		for a, answer := range answers {
+			if got, want := m.Pattern(g, a), EncodePattern(answer.ComputePattern(guess)); got != want {
+				t.Fatalf("guess %s answer %s: expected %d, got %d", guess, answer, want, got)
+			}
+		}
+	}
+
+	universe := NewUniverse(answers)
+	for _, tc := range []struct {
+		Name    string
+		Matrix  func([]int, int) []ScoredGuess
+		Library func([]WordleWord, []WordleWord, int) []ScoredGuess
+	}{
+		{Name: "entropy", Matrix: m.RankGuesses, Library: RankGuesses},
+		{Name: "minimax", Matrix: m.RankGuessesMinimax, Library: RankGuessesMinimax},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.Matrix(m.CandidateIndices(universe), 5)
+			want := tc.Library(guesses, universe.Candidates(), 5)
+			if len(got) != len(want) {
+				t.Fatalf("expected %d guesses, got %d", len(want), len(got))
+			}
+			for n := range want {
+				if got[n] != want[n] {
+					t.Errorf("rank %d: expected %+v, got %+v", n, want[n], got[n])
+				}
+			}
+		})
+	}
+}
+
+func TestReadPatternMatrix(t *testing.T) {
+	t.Parallel()
+
+	answers := mustWords(t, "bakes", "cakes", "makes")
+	guesses := append(mustWords(t, "cflmz"), answers...)
+	var b bytes.Buffer
+	if _, err := NewPatternMatrix(guesses, answers).WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	data := b.Bytes()
+	corruptPattern := bytes.Clone(data)
+	corruptPattern[len(data)-1] ^= 1
+	// the first shard checksum directly follows the word counts
+	corruptChecksum := bytes.Clone(data)
+	corruptChecksum[binary.Size(matrixHeader{})-4*matrixShards] ^= 1
+
+	for _, tc := range []struct {
+		Name    string
+		Data    []byte
+		Guesses []WordleWord
+		Answers []WordleWord
+		Stale   bool
+		Corrupt bool
+		Err     bool
+	}{
+		{Name: "round trip", Data: data, Guesses: guesses, Answers: answers},
+		{Name: "other answers", Data: data, Guesses: guesses, Answers: mustWords(t, "bakes", "cakes", "takes"), Stale: true},
+		{Name: "fewer guesses", Data: data, Guesses: guesses[1:], Answers: answers, Stale: true},
+		{Name: "truncated header", Data: data[:8], Guesses: guesses, Answers: answers, Err: true},
+		{Name: "truncated patterns", Data: data[:len(data)-1], Guesses: guesses, Answers: answers, Err: true},
+		{Name: "corrupt pattern", Data: corruptPattern, Guesses: guesses, Answers: answers, Corrupt: true},
+		{Name: "corrupt checksum", Data: corruptChecksum, Guesses: guesses, Answers: answers, Corrupt: true},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := ReadPatternMatrix(bytes.NewReader(tc.Data), tc.Guesses, tc.Answers)
+			if tc.Stale || tc.Corrupt || tc.Err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if errors.Is(err, ErrStaleMatrix) != tc.Stale {
+					t.Fatalf("expected stale %t, got %v", tc.Stale, err)
+				}
+				if errors.Is(err, ErrCorruptMatrix) != tc.Corrupt {
+					t.Fatalf("expected corrupt %t, got %v", tc.Corrupt, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for g, guess := range tc.Guesses {
+				for a, answer := range tc.Answers {
+					if got, want := m.Pattern(g, a), EncodePattern(answer.ComputePattern(guess)); got != want {
+						t.Fatalf("guess %s answer %s: expected %d, got %d", guess, answer, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPatternMatrixVerify(t *testing.T) {
+	t.Parallel()
+
+	answers := mustWords(t, "bakes", "cakes", "makes")
+	guesses := append(mustWords(t, "cflmz"), answers...)
+
+	for _, tc := range []struct {
+		Name    string
+		Corrupt bool
+	}{
+		{Name: "intact"},
+		{Name: "corrupt", Corrupt: true},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewPatternMatrix(guesses, answers)
+			if tc.Corrupt {
+				m.codes[len(m.codes)-1]++
+			}
+			// the sample is many times the number of patterns, so that every
+			// pattern is checked
+			r := rand.New(rand.NewPCG(1, 0))
+			if err := m.VerifySample(r, 64*len(m.codes)); errors.Is(err, ErrCorruptMatrix) != tc.Corrupt {
+				t.Fatalf("sample: expected corrupt %t, got %v", tc.Corrupt, err)
+			}
+			if err := m.Verify(); errors.Is(err, ErrCorruptMatrix) != tc.Corrupt {
+				t.Fatalf("full: expected corrupt %t, got %v", tc.Corrupt, err)
+			}
+		})
+	}
+}
+
+func loadBenchLists(b *testing.B) ([]WordleWord, []WordleWord) {
+	b.Helper()
+	answers, _, err := LoadEmbeddedWordList(LoadOpts{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	guesses, _, err := LoadEmbeddedGuessList(LoadOpts{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return guesses, answers
+}
+
+// BenchmarkEntropyComputePattern and BenchmarkEntropyPatternMatrix each score
+// every guess against every answer once
+func BenchmarkEntropyComputePattern(b *testing.B) {
+	guesses, answers := loadBenchLists(b)
+	buckets := make([]int, PatternCount(DefaultWordLen))
+	b.ResetTimer()
+	for range b.N {
+		for _, g := range guesses {
+			clear(buckets)
+			for _, a := range answers {
+				buckets[EncodePattern(a.ComputePattern(g))]++
+			}
+			scoreBuckets(buckets, len(answers))
+		}
+	}
+}
+
+func BenchmarkEntropyPatternMatrix(b *testing.B) {
+	guesses, answers := loadBenchLists(b)
+	m := NewPatternMatrix(guesses, answers)
+	candidates := make([]int, len(answers))
+	for i := range candidates {
+		candidates[i] = i
+	}
+	buckets := make([]int, PatternCount(DefaultWordLen))
+	b.ResetTimer()
+	for range b.N {
+		for g := range guesses {
+			m.score(g, candidates, buckets)
+		}
+	}
+}
+
+func BenchmarkBuildPatternMatrix(b *testing.B) {
+	guesses, answers := loadBenchLists(b)
+	b.ResetTimer()
+	for range b.N {
+		BuildPatternMatrix(guesses, answers)
+	}
+}
+
+// BenchmarkVerifySample checks the sample size used when loading a cache of
+// the full lists
+func BenchmarkVerifySample(b *testing.B) {
+	guesses, answers := loadBenchLists(b)
+	m := NewPatternMatrix(guesses, answers)
+	r := rand.New(rand.NewPCG(1, 0))
+	b.ResetTimer()
+	for range b.N {
+		if err := m.VerifySample(r, 4096); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
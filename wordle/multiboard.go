@@ -0,0 +1,46 @@
+package wordle
+
+import (
+	"cmp"
+	"slices"
+)
+
+// RankGuessesMultiBoard scores each guess of pool on every universe and sums
+// the scores, so that guesses are ranked by the total expected reduction in
+// candidates across the boards. A guess is a candidate if it is one on any
+// board, and its worst case is the largest of the boards.
+func RankGuessesMultiBoard(pool []WordleWord, universes []Universe, topN int) []ScoredGuess {
+	totals := make([]ScoredGuess, len(pool))
+	index := make(map[WordleWord]int, len(pool))
+	for i, v := range pool {
+		totals[i].Word = v
+		index[v] = i
+	}
+	for _, u := range universes {
+		for _, v := range RankGuesses(pool, u.Candidates(), len(pool)) {
+			t := &totals[index[v.Word]]
+			t.Entropy += v.Entropy
+			t.ExpectedRemaining += v.ExpectedRemaining
+			t.WorstCase = max(t.WorstCase, v.WorstCase)
+			t.Candidate = t.Candidate || v.Candidate
+		}
+	}
+	slices.SortStableFunc(totals, compareMultiBoard)
+	if len(totals) > topN {
+		totals = totals[:topN]
+	}
+	return totals
+}
+
+func compareMultiBoard(a, b ScoredGuess) int {
+	if c := cmp.Compare(a.ExpectedRemaining, b.ExpectedRemaining); c != 0 {
+		return c
+	}
+	if a.Candidate != b.Candidate {
+		if a.Candidate {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Compare(b.Entropy, a.Entropy)
+}
@@ -0,0 +1,95 @@
+package wordle
+
+import (
+	"cmp"
+	"slices"
+)
+
+type (
+	// OpeningScore is the expected outcome of playing a fixed sequence of
+	// guesses before adapting to feedback
+	OpeningScore struct {
+		Words []WordleWord
+		// ExpectedRemaining is the expected number of candidates left on a
+		// single board after the opening
+		ExpectedRemaining float64
+		// ExpectedTotal is ExpectedRemaining summed over independent boards
+		ExpectedTotal float64
+		// Identified is the fraction of answers left as the only candidate
+		Identified float64
+	}
+)
+
+// RankOpenings searches every fixed opening of length guesses drawn from the
+// width most informative words of pool, ranking them by the expected number
+// of candidates remaining across boards
+func RankOpenings(pool, answers []WordleWord, length, width, boards, topN int) []OpeningScore {
+	if len(answers) == 0 || length < 1 {
+		return nil
+	}
+	top := RankGuesses(pool, answers, width)
+	if len(top) < length {
+		return nil
+	}
+	answerLetters := toLetterWords(answers)
+	// pattern table of every top guess against every answer
+	table := make([][]int, len(top))
+	for i, v := range top {
+		g := toLetterWord(v.Word)
+		row := make([]int, len(answerLetters))
+		for j, t := range answerLetters {
+			row[j] = patternCode(t, g)
+		}
+		table[i] = row
+	}
+
+	total := float64(len(answers))
+	var scores []OpeningScore
+	idx := make([]int, length)
+	buckets := map[int]int{}
+	var search func(depth, start int)
+	search = func(depth, start int) {
+		if depth == length {
+			clear(buckets)
+			for j := range answers {
+				key := 0
+				for _, i := range idx {
+					key = key*NumPatterns + table[i][j]
+				}
+				buckets[key]++
+			}
+			var expected float64
+			identified := 0
+			for _, count := range buckets {
+				expected += float64(count) * float64(count) / total
+				if count == 1 {
+					identified++
+				}
+			}
+			words := make([]WordleWord, 0, length)
+			for _, i := range idx {
+				words = append(words, top[i].Word)
+			}
+			scores = append(scores, OpeningScore{
+				Words:             words,
+				ExpectedRemaining: expected,
+				ExpectedTotal:     expected * float64(boards),
+				Identified:        float64(identified) / total,
+			})
+			return
+		}
+		for i := start; i < len(top); i++ {
+			idx[depth] = i
+			search(depth+1, i+1)
+		}
+	}
+	search(0, 0)
+
+	slices.SortStableFunc(scores, func(a, b OpeningScore) int {
+		return cmp.Compare(a.ExpectedTotal, b.ExpectedTotal)
+	})
+	if len(scores) > topN {
+		scores = scores[:topN]
+	}
+	return scores
+}
@@ -0,0 +1,74 @@
+package wordle
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+type (
+	ProbeSuggestion struct {
+		Word    WordleWord
+		Covered uint32
+		Entropy float64
+	}
+)
+
+func BuildProbes(letters uint32, universe Universe, words []WordleWord, topN int) []ProbeSuggestion {
+	candidates := toLetterWords(universe.Candidates())
+	// group guesses by number of requested letters covered so that only the
+	// best covering tiers need to be scored against the candidates
+	tiers := make([][]WordleWord, bits.OnesCount32(letters)+1)
+	for _, v := range words {
+		n := bits.OnesCount32(v.CharSet() & letters)
+		if n == 0 {
+			continue
+		}
+		tiers[n] = append(tiers[n], v)
+	}
+	buckets := make([]int, PatternCount(universe.Len()))
+	var probes []ProbeSuggestion
+	for n := len(tiers) - 1; n > 0 && len(probes) < topN; n-- {
+		tier := make([]ProbeSuggestion, 0, len(tiers[n]))
+		for _, v := range tiers[n] {
+			tier = append(tier, ProbeSuggestion{
+				Word:    v,
+				Covered: v.CharSet() & letters,
+				Entropy: scoreGuess(toLetterWord(v), candidates, buckets).Entropy,
+			})
+		}
+		slices.SortStableFunc(tier, func(a, b ProbeSuggestion) int {
+			return cmp.Compare(b.Entropy, a.Entropy)
+		})
+		probes = append(probes, tier...)
+	}
+	if len(probes) > topN {
+		probes = probes[:topN]
+	}
+	return probes
+}
+
+func ParseCharSet(s string) (uint32, error) {
+	var set uint32
+	for _, i := range strings.ToUpper(s) {
+		if i == ',' || unicode.IsSpace(i) {
+			continue
+		}
+		if i < 'A' || i > 'Z' {
+			return 0, ErrWordChar
+		}
+		set |= 1 << (i - 'A')
+	}
+	return set, nil
+}
+
+func CharSetString(set uint32) string {
+	var b strings.Builder
+	for set != 0 {
+		b.WriteByte(byte(bits.TrailingZeros32(set)) + 'A')
+		set &= set - 1
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package wordle
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+)
+
+type (
+	ScoredWord struct {
+		Word  WordleWord
+		Score float64
+	}
+)
+
+// RankCandidates orders words by how common their letters are among the
+// remaining candidates of universe, counting each distinct letter once, so
+// that words sharing the most letters with the rest come first
+func RankCandidates(words []WordleWord, universe Universe) []ScoredWord {
+	var freq [26]int
+	count := 0
+	for _, v := range universe.Candidates() {
+		for c := v.CharSet(); c != 0; c &= c - 1 {
+			freq[bits.TrailingZeros32(c)]++
+		}
+		count++
+	}
+	ranked := make([]ScoredWord, 0, len(words))
+	for _, v := range words {
+		score := 0
+		for c := v.CharSet(); c != 0; c &= c - 1 {
+			score += freq[bits.TrailingZeros32(c)]
+		}
+		var s float64
+		if count > 0 {
+			s = float64(score) / float64(count)
+		}
+		ranked = append(ranked, ScoredWord{
+			Word:  v,
+			Score: s,
+		})
+	}
+	slices.SortStableFunc(ranked, func(a, b ScoredWord) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return ranked
+}
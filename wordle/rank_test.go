@@ -0,0 +1,61 @@
+package wordle
+
+import (
+	"testing"
+)
+
+func TestRankCandidates(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name     string
+		Universe []string
+		Words    []string
+		Order    []string
+		Scores   []float64
+	}{
+		{
+			Name:     "common letters first",
+			Universe: []string{"arise", "arose", "fuzzy", "stoke"},
+			Words:    []string{"fuzzy", "stoke", "arise", "arose"},
+			Order:    []string{"arose", "arise", "stoke", "fuzzy"},
+			Scores:   []float64{3, 2.75, 2.5, 1},
+		},
+		{
+			Name:     "ties keep input order",
+			Universe: []string{"abcde", "edcba"},
+			Words:    []string{"edcba", "abcde"},
+			Order:    []string{"edcba", "abcde"},
+			Scores:   []float64{5, 5},
+		},
+		{
+			Name:     "repeated letters count once",
+			Universe: []string{"eerie", "eider"},
+			Words:    []string{"eerie", "eider"},
+			Order:    []string{"eider", "eerie"},
+			Scores:   []float64{3.5, 3},
+		},
+		{
+			Name:     "words outside the candidates",
+			Universe: []string{"arise", "arose"},
+			Words:    []string{"fuzzy", "arise"},
+			Order:    []string{"arise", "fuzzy"},
+			Scores:   []float64{4.5, 0},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			universe := NewUniverse(mustWords(t, tc.Universe...))
+			ranked := RankCandidates(mustWords(t, tc.Words...), universe)
+			if len(ranked) != len(tc.Order) {
+				t.Fatalf("expected %d ranked words, got %d", len(tc.Order), len(ranked))
+			}
+			for n, v := range ranked {
+				if v.Word != mustWords(t, tc.Order[n])[0] || v.Score != tc.Scores[n] {
+					t.Errorf("rank %d: expected %s %v, got %s %v", n, tc.Order[n], tc.Scores[n], v.Word, v.Score)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrShareStyle = errors.New("Unknown share style, expected emoji, ascii, or auto")
+
+type (
+	// ShareStyle is how the squares of a share grid are drawn
+	ShareStyle int
+)
+
+const (
+	// ShareLetters draws each square as its B, Y, or G mark
+	ShareLetters ShareStyle = iota
+	// ShareEmoji draws each square as a colored emoji square
+	ShareEmoji
+	// ShareASCII draws each square as a [#], [o], or [.] block, which still
+	// reads as a grid where emoji are not supported
+	ShareASCII
+)
+
+// ParseShareStyle parses emoji, ascii, or auto, which detects emoji support
+// from the TERM environment variable term
+func ParseShareStyle(s string, term string) (ShareStyle, error) {
+	switch s {
+	case "emoji":
+		return ShareEmoji, nil
+	case "ascii":
+		return ShareASCII, nil
+	case "auto":
+		return DetectShareStyle(term), nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrShareStyle, s)
+	}
+}
+
+// DetectShareStyle returns ShareASCII for terminals that are known to lack
+// emoji glyphs, such as the linux console and serial terminals, and
+// ShareEmoji otherwise
+func DetectShareStyle(term string) ShareStyle {
+	switch {
+	case term == "", term == "dumb", term == "linux", term == "cons25", strings.HasPrefix(term, "vt"):
+		return ShareASCII
+	default:
+		return ShareEmoji
+	}
+}
+
+// Emoji renders the pattern as the squares of a Wordle share grid
+func (p WordlePattern) Emoji() string {
+	var b strings.Builder
+	for _, v := range p[:p.Len()] {
+		switch v.kind {
+		case PatternKindB:
+			b.WriteString("⬛")
+		case PatternKindY:
+			b.WriteString("\U0001f7e8")
+		case PatternKindG:
+			b.WriteString("\U0001f7e9")
+		}
+	}
+	return b.String()
+}
+
+// ASCII renders the pattern as the blocks of a plain text share grid
+func (p WordlePattern) ASCII() string {
+	var b strings.Builder
+	for _, v := range p[:p.Len()] {
+		switch v.kind {
+		case PatternKindB:
+			b.WriteString("[.]")
+		case PatternKindY:
+			b.WriteString("[o]")
+		case PatternKindG:
+			b.WriteString("[#]")
+		}
+	}
+	return b.String()
+}
+
+// ShareGrid returns the share block for the patterns received in a game,
+// scored X if it was lost, with squares drawn in style
+func ShareGrid(patterns []WordlePattern, maxGuesses int, won bool, style ShareStyle) string {
+	var b strings.Builder
+	score := "X"
+	if won {
+		score = fmt.Sprint(len(patterns))
+	}
+	fmt.Fprintf(&b, "wordlebot %s/%d\n", score, maxGuesses)
+	for _, v := range patterns {
+		b.WriteByte('\n')
+		switch style {
+		case ShareEmoji:
+			b.WriteString(v.Emoji())
+		case ShareASCII:
+			b.WriteString(v.ASCII())
+		default:
+			b.WriteString(v.Compact())
+		}
+	}
+	return b.String()
+}
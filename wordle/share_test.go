@@ -0,0 +1,166 @@
+package wordle
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func mustPatterns(t testing.TB, guess string, feedback ...string) []WordlePattern {
+	t.Helper()
+	k := make([]WordlePattern, 0, len(feedback))
+	for _, v := range feedback {
+		p, err := ParsePattern(guess, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k = append(k, p)
+	}
+	return k
+}
+
+func TestPatternEmoji(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Feedback string
+		Emoji    string
+	}{
+		{Feedback: "BBBBB", Emoji: "⬛⬛⬛⬛⬛"},
+		{Feedback: "YYYYY", Emoji: "🟨🟨🟨🟨🟨"},
+		{Feedback: "GGGGG", Emoji: "🟩🟩🟩🟩🟩"},
+		{Feedback: "GYBYG", Emoji: "🟩🟨⬛🟨🟩"},
+	} {
+		t.Run(tc.Feedback, func(t *testing.T) {
+			t.Parallel()
+
+			if got := mustPatterns(t, "crane", tc.Feedback)[0].Emoji(); got != tc.Emoji {
+				t.Fatalf("expected %s, got %s", tc.Emoji, got)
+			}
+		})
+	}
+}
+
+func TestShareGrid(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name     string
+		Feedback []string
+		Won      bool
+		Style    ShareStyle
+		Grid     string
+	}{
+		{
+			Name:     "win",
+			Feedback: []string{"BYBBB", "GGGGG"},
+			Won:      true,
+			Style:    ShareEmoji,
+			Grid:     "wordlebot 2/6\n\n⬛🟨⬛⬛⬛\n🟩🟩🟩🟩🟩",
+		},
+		{
+			Name:     "win letters",
+			Feedback: []string{"BYBBB", "GGGGG"},
+			Won:      true,
+			Style:    ShareLetters,
+			Grid:     "wordlebot 2/6\n\nBYBBB\nGGGGG",
+		},
+		{
+			Name:     "win ascii",
+			Feedback: []string{"BYBBB", "GGGGG"},
+			Won:      true,
+			Style:    ShareASCII,
+			Grid:     "wordlebot 2/6\n\n[.][o][.][.][.]\n[#][#][#][#][#]",
+		},
+		{
+			Name:     "first guess",
+			Feedback: []string{"GGGGG"},
+			Won:      true,
+			Style:    ShareEmoji,
+			Grid:     "wordlebot 1/6\n\n🟩🟩🟩🟩🟩",
+		},
+		{
+			Name:     "loss",
+			Feedback: []string{"BBBBB", "BBBBB", "BBBBB", "BBBBB", "BBBBB", "GGGGB"},
+			Style:    ShareLetters,
+			Grid:     "wordlebot X/6\n\nBBBBB\nBBBBB\nBBBBB\nBBBBB\nBBBBB\nGGGGB",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ShareGrid(mustPatterns(t, "crane", tc.Feedback...), 6, tc.Won, tc.Style); got != tc.Grid {
+				t.Fatalf("expected %q, got %q", tc.Grid, got)
+			}
+		})
+	}
+}
+
+func TestShareGridGolden(t *testing.T) {
+	t.Parallel()
+
+	// every style draws the same game
+	patterns := mustPatterns(t, "crane", "BYBBB", "GYBYB", "GGGGG")
+
+	for _, tc := range []struct {
+		Name  string
+		Style ShareStyle
+	}{
+		{Name: "share_emoji", Style: ShareEmoji},
+		{Name: "share_ascii", Style: ShareASCII},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			grid := ShareGrid(patterns, 6, true, tc.Style) + "\n"
+			name := filepath.Join("testdata", tc.Name+".golden")
+			if *update {
+				if err := os.WriteFile(name, []byte(grid), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			golden, err := os.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if grid != string(golden) {
+				t.Fatalf("expected %s, got %s", golden, grid)
+			}
+		})
+	}
+}
+
+func TestParseShareStyle(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name  string
+		Style string
+		Term  string
+		Want  ShareStyle
+		Err   error
+	}{
+		{Name: "emoji", Style: "emoji", Term: "dumb", Want: ShareEmoji},
+		{Name: "ascii", Style: "ascii", Term: "xterm-256color", Want: ShareASCII},
+		{Name: "auto xterm", Style: "auto", Term: "xterm-256color", Want: ShareEmoji},
+		{Name: "auto tmux", Style: "auto", Term: "tmux-256color", Want: ShareEmoji},
+		{Name: "auto unset", Style: "auto", Term: "", Want: ShareASCII},
+		{Name: "auto dumb", Style: "auto", Term: "dumb", Want: ShareASCII},
+		{Name: "auto linux console", Style: "auto", Term: "linux", Want: ShareASCII},
+		{Name: "auto serial", Style: "auto", Term: "vt100", Want: ShareASCII},
+		{Name: "unknown", Style: "color", Err: ErrShareStyle},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			style, err := ParseShareStyle(tc.Style, tc.Term)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+			if err == nil && style != tc.Want {
+				t.Fatalf("expected style %d, got %d", tc.Want, style)
+			}
+		})
+	}
+}
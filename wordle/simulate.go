@@ -0,0 +1,207 @@
+package wordle
+
+import (
+	"time"
+)
+
+const (
+	// MaxSimulatedGuesses bounds a simulated game so that a misbehaving
+	// strategy cannot loop forever
+	MaxSimulatedGuesses = 32
+)
+
+type (
+	// Strategy picks the next guess for a universe. turn is the number of
+	// guesses already made.
+	Strategy interface {
+		Name() string
+		NextGuess(u Universe, turn int) WordleWord
+	}
+
+	FirstCandidateStrategy struct{}
+
+	EntropyStrategy struct {
+		pool   []WordleWord
+		hard   bool
+		opener *WordleWord
+	}
+
+	MinimaxStrategy struct {
+		pool   []WordleWord
+		hard   bool
+		opener *WordleWord
+	}
+
+	// OpenerStrategy plays a fixed opening guess before deferring to another
+	// strategy
+	OpenerStrategy struct {
+		strategy Strategy
+		opener   WordleWord
+	}
+
+	SimulationResult struct {
+		Strategy     string        `json:"strategy"`
+		Games        int           `json:"games"`
+		Average      float64       `json:"average"`
+		Histogram    []int         `json:"histogram"`
+		Failures     int           `json:"failures"`
+		WorstGuesses int           `json:"worst_guesses"`
+		Worst        []string      `json:"worst"`
+		Runtime      time.Duration `json:"runtime_ns"`
+	}
+)
+
+func (s FirstCandidateStrategy) Name() string {
+	return "first"
+}
+
+func (s FirstCandidateStrategy) NextGuess(u Universe, turn int) WordleWord {
+	if i := u.candidates.Next(0); i >= 0 {
+		return u.words[i]
+	}
+	return WordleWord{}
+}
+
+func NewEntropyStrategy(pool []WordleWord) *EntropyStrategy {
+	return &EntropyStrategy{
+		pool: pool,
+	}
+}
+
+// NewHardModeEntropyStrategy only guesses words that use every hint
+// revealed so far
+func NewHardModeEntropyStrategy(pool []WordleWord) *EntropyStrategy {
+	return &EntropyStrategy{
+		pool: pool,
+		hard: true,
+	}
+}
+
+func (s *EntropyStrategy) Name() string {
+	if s.hard {
+		return "entropy-hard"
+	}
+	return "entropy"
+}
+
+func (s *EntropyStrategy) NextGuess(u Universe, turn int) WordleWord {
+	// every game starts from the same universe, so the opener is only
+	// computed once
+	if turn == 0 {
+		if s.opener == nil {
+			w := SuggestGuess(u, s.pool)
+			s.opener = &w
+		}
+		return *s.opener
+	}
+	if s.hard {
+		return SuggestGuess(u, u.HardModeGuesses(s.pool))
+	}
+	return SuggestGuess(u, s.pool)
+}
+
+func NewMinimaxStrategy(pool []WordleWord) *MinimaxStrategy {
+	return &MinimaxStrategy{
+		pool: pool,
+	}
+}
+
+func NewHardModeMinimaxStrategy(pool []WordleWord) *MinimaxStrategy {
+	return &MinimaxStrategy{
+		pool: pool,
+		hard: true,
+	}
+}
+
+func (s *MinimaxStrategy) Name() string {
+	if s.hard {
+		return "minimax-hard"
+	}
+	return "minimax"
+}
+
+func (s *MinimaxStrategy) NextGuess(u Universe, turn int) WordleWord {
+	if turn == 0 {
+		if s.opener == nil {
+			w := SuggestGuessMinimax(u, s.pool)
+			s.opener = &w
+		}
+		return *s.opener
+	}
+	if s.hard {
+		return SuggestGuessMinimax(u, u.HardModeGuesses(s.pool))
+	}
+	return SuggestGuessMinimax(u, s.pool)
+}
+
+func NewOpenerStrategy(strategy Strategy, opener WordleWord) *OpenerStrategy {
+	return &OpenerStrategy{
+		strategy: strategy,
+		opener:   opener,
+	}
+}
+
+func (s *OpenerStrategy) Name() string {
+	return s.strategy.Name() + "+" + s.opener.String()
+}
+
+func (s *OpenerStrategy) NextGuess(u Universe, turn int) WordleWord {
+	if turn == 0 {
+		return s.opener
+	}
+	return s.strategy.NextGuess(u, turn)
+}
+
+// PlayGame plays strategy against target from universe and returns the
+// number of guesses needed to guess the target. A game that leaves no
+// candidates, as for a target outside of the universe, or for which the
+// strategy has no guess is lost.
+func PlayGame(target WordleWord, strategy Strategy, universe Universe) int {
+	for turn := 0; turn < MaxSimulatedGuesses; turn++ {
+		if universe.Count() == 0 {
+			break
+		}
+		guess := strategy.NextGuess(universe, turn)
+		if guess == target {
+			return turn + 1
+		}
+		if guess == (WordleWord{}) {
+			break
+		}
+		universe = universe.ApplyPattern(target.ComputePattern(guess))
+	}
+	return MaxSimulatedGuesses + 1
+}
+
+// Simulate plays a game against every target and aggregates the guess
+// counts, where games taking more than maxGuesses are failures
+func Simulate(targets []WordleWord, strategy Strategy, universe Universe, maxGuesses int) SimulationResult {
+	start := time.Now()
+	res := SimulationResult{
+		Strategy:  strategy.Name(),
+		Games:     len(targets),
+		Histogram: make([]int, maxGuesses),
+	}
+	total := 0
+	for _, v := range targets {
+		n := PlayGame(v, strategy, universe)
+		total += n
+		if n > maxGuesses {
+			res.Failures++
+		} else {
+			res.Histogram[n-1]++
+		}
+		if n > res.WorstGuesses {
+			res.WorstGuesses = n
+			res.Worst = res.Worst[:0]
+		}
+		if n == res.WorstGuesses {
+			res.Worst = append(res.Worst, v.String())
+		}
+	}
+	if len(targets) > 0 {
+		res.Average = float64(total) / float64(len(targets))
+	}
+	res.Runtime = time.Since(start)
+	return res
+}
@@ -0,0 +1,124 @@
+package wordle
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPlayGame(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "fakes", "lakes", "makes")
+	// the probe separates every answer, so it is the only guess worth more
+	// than a candidate
+	pool := MergeWords(words, mustWords(t, "cflmz"))
+	universe := NewUniverse(words)
+
+	for _, tc := range []struct {
+		Name      string
+		Strategy  Strategy
+		Guesses   []int
+		Histogram []int
+		Failures  int
+		Worst     []string
+	}{
+		{
+			Name:      "first",
+			Strategy:  FirstCandidateStrategy{},
+			Guesses:   []int{1, 2, 3, 4, 5},
+			Histogram: []int{1, 1, 1},
+			Failures:  2,
+			Worst:     []string{"MAKES"},
+		},
+		{
+			Name:      "entropy",
+			Strategy:  NewEntropyStrategy(pool),
+			Guesses:   []int{2, 2, 2, 2, 2},
+			Histogram: []int{0, 5, 0},
+			Worst:     []string{"BAKES", "CAKES", "FAKES", "LAKES", "MAKES"},
+		},
+		{
+			Name:      "minimax",
+			Strategy:  NewMinimaxStrategy(pool),
+			Guesses:   []int{2, 2, 2, 2, 2},
+			Histogram: []int{0, 5, 0},
+			Worst:     []string{"BAKES", "CAKES", "FAKES", "LAKES", "MAKES"},
+		},
+		{
+			Name:      "first with opener",
+			Strategy:  NewOpenerStrategy(FirstCandidateStrategy{}, words[4]),
+			Guesses:   []int{2, 3, 4, 5, 1},
+			Histogram: []int{1, 1, 1},
+			Failures:  2,
+			Worst:     []string{"LAKES"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			for i, v := range words {
+				if n := PlayGame(v, tc.Strategy, universe); n != tc.Guesses[i] {
+					t.Errorf("target %s: expected %d guesses, got %d", v, tc.Guesses[i], n)
+				}
+			}
+			res := Simulate(words, tc.Strategy, universe, 3)
+			if res.Games != len(words) || res.Failures != tc.Failures {
+				t.Errorf("expected %d games and %d failures, got %d and %d", len(words), tc.Failures, res.Games, res.Failures)
+			}
+			if !slices.Equal(res.Histogram, tc.Histogram) {
+				t.Errorf("expected histogram %v, got %v", tc.Histogram, res.Histogram)
+			}
+			total := 0
+			for _, v := range tc.Guesses {
+				total += v
+			}
+			if avg := float64(total) / float64(len(words)); res.Average != avg {
+				t.Errorf("expected average %f, got %f", avg, res.Average)
+			}
+			if !slices.Equal(res.Worst, tc.Worst) {
+				t.Errorf("expected worst %v, got %v", tc.Worst, res.Worst)
+			}
+		})
+	}
+}
+
+type (
+	noGuessStrategy struct{}
+)
+
+func (s noGuessStrategy) Name() string {
+	return "none"
+}
+
+func (s noGuessStrategy) NextGuess(u Universe, turn int) WordleWord {
+	return WordleWord{}
+}
+
+func TestPlayGameLoss(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "fakes", "lakes", "makes")
+	universe := NewUniverse(words)
+	// a guess only word that no answer matches
+	target := mustWords(t, "aahed")[0]
+
+	for _, tc := range []struct {
+		Name     string
+		Target   WordleWord
+		Strategy Strategy
+	}{
+		{Name: "target not in universe", Target: target, Strategy: FirstCandidateStrategy{}},
+		{Name: "no guess", Target: words[0], Strategy: noGuessStrategy{}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if n := PlayGame(tc.Target, tc.Strategy, universe); n != MaxSimulatedGuesses+1 {
+				t.Fatalf("expected a loss, got %d guesses", n)
+			}
+			if res := Simulate([]WordleWord{tc.Target}, tc.Strategy, universe, 6); res.Failures != 1 {
+				t.Fatalf("expected 1 failure, got %+v", res)
+			}
+		})
+	}
+}
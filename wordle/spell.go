@@ -0,0 +1,38 @@
+package wordle
+
+// IsNeighbor reports whether a and b differ by exactly one letter substitution
+// or one transposition of adjacent letters
+func IsNeighbor(a, b WordleWord) bool {
+	var diff [2]int
+	n := 0
+	for i := range a {
+		if a[i] != b[i] {
+			if n == 2 {
+				return false
+			}
+			diff[n] = i
+			n++
+		}
+	}
+	switch n {
+	case 1:
+		return true
+	case 2:
+		i, j := diff[0], diff[1]
+		return j == i+1 && a[i] == b[j] && a[j] == b[i]
+	default:
+		return false
+	}
+}
+
+// Neighbors returns the words that are one substitution or adjacent
+// transposition away from w
+func Neighbors(w WordleWord, words []WordleWord) []WordleWord {
+	var k []WordleWord
+	for _, i := range words {
+		if IsNeighbor(w, i) {
+			k = append(k, i)
+		}
+	}
+	return k
+}
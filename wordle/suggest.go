@@ -0,0 +1,209 @@
+package wordle
+
+import (
+	"cmp"
+	"math"
+	"math/bits"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+type (
+	ScoredGuess struct {
+		Word              WordleWord
+		Entropy           float64
+		ExpectedRemaining float64
+		// WorstCase is the size of the largest pattern bucket
+		WorstCase int
+		Candidate bool
+	}
+
+	// letterWord holds the letter index of each position, which is cheaper to
+	// compute patterns over than the bitmask representation
+	letterWord struct {
+		l [MaxWordLen]uint8
+		n int
+	}
+)
+
+func toLetterWord(w WordleWord) letterWord {
+	l := letterWord{
+		n: w.Len(),
+	}
+	for i, v := range w[:l.n] {
+		l.l[i] = uint8(bits.TrailingZeros32(v))
+	}
+	return l
+}
+
+func toLetterWords(words []WordleWord) []letterWord {
+	l := make([]letterWord, 0, len(words))
+	for _, v := range words {
+		l = append(l, toLetterWord(v))
+	}
+	return l
+}
+
+// patternCode computes the same value as target.ComputePattern(guess).Code()
+// without materializing the pattern
+func patternCode(target, guess letterWord) int {
+	var remaining [32]int8
+	var green uint8
+	g := guess.l[:guess.n]
+	t := target.l[:len(g)]
+	for i, v := range g {
+		if t[i] == v {
+			green |= 1 << i
+		} else {
+			remaining[t[i]&31]++
+		}
+	}
+	code := 0
+	for i := len(g) - 1; i >= 0; i-- {
+		code *= 3
+		if green&(1<<i) != 0 {
+			code += int(PatternKindG)
+		}
+	}
+	mult := 1
+	for i, v := range g {
+		if green&(1<<i) == 0 && remaining[v&31] > 0 {
+			remaining[v&31]--
+			code += mult * int(PatternKindY)
+		}
+		mult *= 3
+	}
+	return code
+}
+
+func SuggestGuess(universe Universe, pool []WordleWord) WordleWord {
+	suggestions := SuggestGuesses(universe, pool, 1)
+	if len(suggestions) == 0 {
+		return WordleWord{}
+	}
+	return suggestions[0].Word
+}
+
+// SuggestGuesses ranks every guess in pool, which need not be possible
+// answers, by how well it splits the remaining candidates in universe
+func SuggestGuesses(universe Universe, pool []WordleWord, topN int) []ScoredGuess {
+	return RankGuesses(pool, universe.Candidates(), topN)
+}
+
+// SuggestGuessMinimax returns the guess in pool whose worst case leaves the
+// fewest candidates
+func SuggestGuessMinimax(universe Universe, pool []WordleWord) WordleWord {
+	suggestions := SuggestGuessesMinimax(universe, pool, 1)
+	if len(suggestions) == 0 {
+		return WordleWord{}
+	}
+	return suggestions[0].Word
+}
+
+func SuggestGuessesMinimax(universe Universe, pool []WordleWord, topN int) []ScoredGuess {
+	return RankGuessesMinimax(pool, universe.Candidates(), topN)
+}
+
+func RankGuesses(pool []WordleWord, candidates []WordleWord, topN int) []ScoredGuess {
+	return rankGuesses(pool, candidates, topN, compareScoredGuess)
+}
+
+func RankGuessesMinimax(pool []WordleWord, candidates []WordleWord, topN int) []ScoredGuess {
+	return rankGuesses(pool, candidates, topN, compareMinimax)
+}
+
+func rankGuesses(pool []WordleWord, candidates []WordleWord, topN int, compare func(a, b ScoredGuess) int) []ScoredGuess {
+	if len(candidates) == 0 {
+		return nil
+	}
+	candidateSet := make(map[WordleWord]struct{}, len(candidates))
+	for _, v := range candidates {
+		candidateSet[v] = struct{}{}
+	}
+	candidateLetters := toLetterWords(candidates)
+	numPatterns := PatternCount(candidateLetters[0].n)
+	scores := make([]ScoredGuess, len(pool))
+	workers := runtime.GOMAXPROCS(0)
+	chunk := (len(pool) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(pool); start += chunk {
+		end := min(start+chunk, len(pool))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buckets := make([]int, numPatterns)
+			for i := start; i < end; i++ {
+				v := pool[i]
+				scores[i] = scoreGuess(toLetterWord(v), candidateLetters, buckets)
+				scores[i].Word = v
+				_, scores[i].Candidate = candidateSet[v]
+			}
+		}()
+	}
+	wg.Wait()
+	slices.SortStableFunc(scores, compare)
+	if len(scores) > topN {
+		scores = scores[:topN]
+	}
+	return scores
+}
+
+func compareScoredGuess(a, b ScoredGuess) int {
+	if c := cmp.Compare(b.Entropy, a.Entropy); c != 0 {
+		return c
+	}
+	if a.Candidate != b.Candidate {
+		if a.Candidate {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+func compareMinimax(a, b ScoredGuess) int {
+	if c := cmp.Compare(a.WorstCase, b.WorstCase); c != 0 {
+		return c
+	}
+	if a.Candidate != b.Candidate {
+		if a.Candidate {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Compare(b.Entropy, a.Entropy)
+}
+
+// scoreGuess buckets candidates by pattern using buckets as scratch space,
+// which must hold every pattern of the guess length
+func scoreGuess(guess letterWord, candidates []letterWord, buckets []int) ScoredGuess {
+	if len(candidates) == 0 {
+		return ScoredGuess{}
+	}
+	clear(buckets)
+	for _, v := range candidates {
+		buckets[patternCode(v, guess)]++
+	}
+	return scoreBuckets(buckets, len(candidates))
+}
+
+func scoreBuckets(buckets []int, n int) ScoredGuess {
+	total := float64(n)
+	var entropy, expected float64
+	worst := 0
+	for _, count := range buckets {
+		if count == 0 {
+			continue
+		}
+		worst = max(worst, count)
+		p := float64(count) / total
+		entropy -= p * math.Log2(p)
+		expected += p * float64(count)
+	}
+	return ScoredGuess{
+		Entropy:           entropy,
+		ExpectedRemaining: expected,
+		WorstCase:         worst,
+	}
+}
@@ -0,0 +1,83 @@
+package wordle
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSuggestGuesses(t *testing.T) {
+	t.Parallel()
+
+	candidates := mustWords(t, "bakes", "cakes", "makes", "takes")
+	// the probe splits every candidate into its own bucket, while each
+	// candidate only separates itself from the other three
+	pool := append(mustWords(t, "bakes", "tbcmz", "cakes", "zzzzz"), candidates[2:]...)
+	universe := NewUniverse(candidates)
+	split := -(0.25*math.Log2(0.25) + 0.75*math.Log2(0.75))
+
+	for _, tc := range []struct {
+		Name     string
+		Rank     func(Universe, []WordleWord, int) []ScoredGuess
+		Expected []ScoredGuess
+	}{
+		{
+			Name: "entropy",
+			Rank: SuggestGuesses,
+			Expected: []ScoredGuess{
+				{Word: mustWords(t, "tbcmz")[0], Entropy: 2, ExpectedRemaining: 1, WorstCase: 1},
+				{Word: candidates[0], Entropy: split, ExpectedRemaining: 2.5, WorstCase: 3, Candidate: true},
+				{Word: candidates[1], Entropy: split, ExpectedRemaining: 2.5, WorstCase: 3, Candidate: true},
+				{Word: candidates[2], Entropy: split, ExpectedRemaining: 2.5, WorstCase: 3, Candidate: true},
+			},
+		},
+		{
+			Name: "minimax",
+			Rank: SuggestGuessesMinimax,
+			Expected: []ScoredGuess{
+				{Word: mustWords(t, "tbcmz")[0], Entropy: 2, ExpectedRemaining: 1, WorstCase: 1},
+				{Word: candidates[0], Entropy: split, ExpectedRemaining: 2.5, WorstCase: 3, Candidate: true},
+				{Word: candidates[1], Entropy: split, ExpectedRemaining: 2.5, WorstCase: 3, Candidate: true},
+				{Word: candidates[2], Entropy: split, ExpectedRemaining: 2.5, WorstCase: 3, Candidate: true},
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.Rank(universe, pool, len(tc.Expected))
+			if len(got) != len(tc.Expected) {
+				t.Fatalf("expected %d suggestions, got %d", len(tc.Expected), len(got))
+			}
+			for i, v := range tc.Expected {
+				g := got[i]
+				if g.Word != v.Word || g.Candidate != v.Candidate || g.WorstCase != v.WorstCase || math.Abs(g.Entropy-v.Entropy) > 1e-9 || math.Abs(g.ExpectedRemaining-v.ExpectedRemaining) > 1e-9 {
+					t.Errorf("suggestion %d: expected %+v, got %+v", i+1, v, g)
+				}
+			}
+		})
+	}
+
+	if got := SuggestGuesses(universe.ApplyPattern(candidates[0].ComputePattern(candidates[0])), pool, 10); len(got) != 6 || got[0].Word != candidates[0] {
+		t.Fatalf("expected the solved answer to rank first, got %+v", got)
+	}
+	if got := SuggestGuesses(universe.WithFilter(func(WordleWord) bool { return false }), pool, 10); got != nil {
+		t.Fatalf("expected no suggestions without candidates, got %+v", got)
+	}
+}
+
+func TestPatternCode(t *testing.T) {
+	t.Parallel()
+
+	words, err := DefaultWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	letters := toLetterWords(words)
+	for i := 0; i < len(words); i += 7 {
+		for j := 0; j < len(words); j += 131 {
+			if got, want := patternCode(letters[i], letters[j]), words[i].ComputePattern(words[j]).Code(); got != want {
+				t.Fatalf("target %s guess %s: expected code %d, got %d", words[i], words[j], want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package wordle
+
+import (
+	"slices"
+)
+
+const (
+	// TurnSchema is the version of the TurnResult schema. Turns without a
+	// schema field are version 1, and version 2 added the suggestion audit.
+	TurnSchema = 2
+
+	// AuditLen is the number of alternatives kept in a SuggestionAudit
+	AuditLen = 5
+)
+
+type (
+	// TurnResult is the machine readable outcome of applying a guess. Audit
+	// holds the suggestions that were shown before the guess, if any.
+	TurnResult struct {
+		Schema     int              `json:"schema"`
+		Guess      string           `json:"guess"`
+		Pattern    []PatternMark    `json:"pattern"`
+		Remaining  int              `json:"remaining"`
+		Candidates []string         `json:"candidates,omitempty"`
+		Audit      *SuggestionAudit `json:"audit,omitempty"`
+	}
+
+	// SuggestionAudit records the strategy and top scored alternatives of a
+	// suggestion so that the choice of a guess can be studied later
+	SuggestionAudit struct {
+		Strategy     string             `json:"strategy"`
+		Alternatives []SuggestionResult `json:"alternatives"`
+	}
+
+	// PatternMark is the mark of a single letter, which is one of B, Y, or G
+	PatternMark struct {
+		Letter string `json:"letter"`
+		Mark   string `json:"mark"`
+	}
+
+	ErrorResult struct {
+		Error string `json:"error"`
+	}
+
+	// UndoResult reports the guess removed by an undo
+	UndoResult struct {
+		Undo      string `json:"undo"`
+		Remaining int    `json:"remaining"`
+	}
+
+	HistoryResult struct {
+		History []TurnResult `json:"history"`
+	}
+
+	// ListingResult is a page of ranked candidates. Hidden counts the listed
+	// words masked by a blocklist, and omitted counts the candidates past the
+	// end of the page.
+	ListingResult struct {
+		Candidates []ListedWord `json:"candidates"`
+		Hidden     int          `json:"hidden,omitempty"`
+		Omitted    int          `json:"omitted,omitempty"`
+	}
+
+	ListedWord struct {
+		Word  string  `json:"word"`
+		Score float64 `json:"score"`
+	}
+
+	SuggestionsResult struct {
+		Suggestions []SuggestionResult `json:"suggestions"`
+		Hidden      int                `json:"hidden,omitempty"`
+		Dump        string             `json:"dump,omitempty"`
+	}
+
+	SuggestionResult struct {
+		Word              string  `json:"word"`
+		Entropy           float64 `json:"entropy"`
+		ExpectedRemaining float64 `json:"expected_remaining"`
+		WorstCase         int     `json:"worst_case"`
+	}
+
+	ProbesResult struct {
+		Probes []ProbeResult `json:"probes"`
+	}
+
+	ProbeResult struct {
+		Word    string  `json:"word"`
+		Covered string  `json:"covered"`
+		Missing string  `json:"missing"`
+		Entropy float64 `json:"entropy"`
+	}
+
+	// RevealResult reveals a random target after the game
+	RevealResult struct {
+		Target string `json:"target"`
+	}
+
+	DefinitionResult struct {
+		Word       string `json:"word"`
+		Definition string `json:"definition"`
+	}
+
+	// AllocResult reports the allocations of a command and the sizes in bytes
+	// of the structures it used
+	AllocResult struct {
+		Alloc  string            `json:"alloc"`
+		Allocs uint64            `json:"allocs"`
+		Bytes  uint64            `json:"bytes"`
+		Sizes  map[string]uint64 `json:"sizes,omitempty"`
+	}
+)
+
+// NewTurnResult describes the universe after pattern was received for guess,
+// listing up to topN remaining candidates
+func NewTurnResult(guess WordleWord, pattern WordlePattern, universe Universe, topN int) TurnResult {
+	res := TurnResult{
+		Schema:    TurnSchema,
+		Guess:     guess.String(),
+		Pattern:   pattern.Marks(),
+		Remaining: universe.Count(),
+	}
+	if topN > 0 {
+		universe.candidates.Range(func(i int) bool {
+			res.Candidates = append(res.Candidates, universe.words[i].String())
+			return len(res.Candidates) < topN
+		})
+	}
+	return res
+}
+
+// NewSuggestionAudit keeps up to AuditLen of the best suggestions made by
+// strategy
+func NewSuggestionAudit(strategy string, suggestions []SuggestionResult) *SuggestionAudit {
+	return &SuggestionAudit{
+		Strategy:     strategy,
+		Alternatives: slices.Clone(suggestions[:min(len(suggestions), AuditLen)]),
+	}
+}
+
+func (p WordlePattern) Marks() []PatternMark {
+	compact := p.Compact()
+	marks := make([]PatternMark, 0, len(compact))
+	for i, v := range p[:len(compact)] {
+		marks = append(marks, PatternMark{
+			Letter: charString(v.v),
+			Mark:   compact[i : i+1],
+		})
+	}
+	return marks
+}
+
+func NewErrorResult(err error) ErrorResult {
+	return ErrorResult{
+		Error: err.Error(),
+	}
+}
@@ -0,0 +1,126 @@
+package wordle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestResultGolden(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "crane", "fakes", "stool")
+	universe := NewUniverse(words)
+	target, guess := words[1], words[0]
+	pattern := target.ComputePattern(guess)
+	after := universe.ApplyPattern(pattern)
+	audited := NewTurnResult(guess, pattern, after, 0)
+	audited.Audit = NewSuggestionAudit("entropy", []SuggestionResult{{Word: "BAKES", Entropy: 1.5, ExpectedRemaining: 1.5, WorstCase: 2}})
+
+	for _, tc := range []struct {
+		Name   string
+		Result any
+	}{
+		{Name: "turn", Result: NewTurnResult(guess, pattern, after, 0)},
+		{Name: "turn_candidates", Result: NewTurnResult(guess, pattern, after, 2)},
+		{Name: "turn_audit", Result: audited},
+		{Name: "error", Result: NewErrorResult(errors.New("Error word not in guess list"))},
+		{Name: "undo", Result: UndoResult{Undo: guess.String(), Remaining: universe.Count()}},
+		{Name: "history", Result: HistoryResult{History: []TurnResult{NewTurnResult(guess, pattern, after, 0)}}},
+		{Name: "listing", Result: ListingResult{
+			Candidates: []ListedWord{{Word: "CAKES", Score: 1.5}, {Word: "█████", Score: 1.25}},
+			Hidden:     1,
+			Omitted:    1,
+		}},
+		{Name: "suggestions", Result: SuggestionsResult{
+			Suggestions: []SuggestionResult{{Word: "CFLMZ", Entropy: 1.5, ExpectedRemaining: 1.5, WorstCase: 2}},
+			Dump:        "dump.json",
+		}},
+		{Name: "probes", Result: ProbesResult{Probes: []ProbeResult{{Word: "CFLMZ", Covered: "CF", Missing: "Q", Entropy: 1.5}}}},
+		{Name: "definition", Result: DefinitionResult{Word: "CRANE", Definition: "a large wading bird"}},
+		{Name: "reveal", Result: RevealResult{Target: "CAKES"}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var b bytes.Buffer
+			if err := json.NewEncoder(&b).Encode(tc.Result); err != nil {
+				t.Fatal(err)
+			}
+			name := filepath.Join("testdata", tc.Name+".golden")
+			if *update {
+				if err := os.WriteFile(name, b.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			golden, err := os.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(b.Bytes(), golden) {
+				t.Fatalf("expected %s, got %s", golden, b.Bytes())
+			}
+		})
+	}
+}
+
+func TestTurnResultDecode(t *testing.T) {
+	t.Parallel()
+
+	suggestions := make([]SuggestionResult, 0, AuditLen+2)
+	for i := range AuditLen + 2 {
+		suggestions = append(suggestions, SuggestionResult{Word: "CRANE", Entropy: float64(AuditLen + 2 - i)})
+	}
+
+	for _, tc := range []struct {
+		Name   string
+		Golden string
+		Audit  *SuggestionAudit
+	}{
+		{Name: "lean", Golden: "turn"},
+		{Name: "audit", Golden: "turn_audit", Audit: &SuggestionAudit{
+			Strategy:     "entropy",
+			Alternatives: []SuggestionResult{{Word: "BAKES", Entropy: 1.5, ExpectedRemaining: 1.5, WorstCase: 2}},
+		}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := os.ReadFile(filepath.Join("testdata", tc.Golden+".golden"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			dec := json.NewDecoder(bytes.NewReader(b))
+			dec.DisallowUnknownFields()
+			var res TurnResult
+			if err := dec.Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if res.Schema != TurnSchema {
+				t.Fatalf("expected schema %d, got %d", TurnSchema, res.Schema)
+			}
+			if (res.Audit == nil) != (tc.Audit == nil) {
+				t.Fatalf("expected audit %+v, got %+v", tc.Audit, res.Audit)
+			}
+			if res.Audit != nil && (res.Audit.Strategy != tc.Audit.Strategy || !slices.Equal(res.Audit.Alternatives, tc.Audit.Alternatives)) {
+				t.Fatalf("expected audit %+v, got %+v", tc.Audit, res.Audit)
+			}
+		})
+	}
+
+	t.Run("top alternatives", func(t *testing.T) {
+		t.Parallel()
+
+		audit := NewSuggestionAudit("minimax", suggestions)
+		if audit.Strategy != "minimax" || !slices.Equal(audit.Alternatives, suggestions[:AuditLen]) {
+			t.Fatalf("expected the top %d suggestions, got %+v", AuditLen, audit)
+		}
+	})
+}
@@ -0,0 +1,308 @@
+package wordle
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	allBits = 0x3ffffff
+)
+
+type (
+	// Universe is the set of words from a word list that are consistent with
+	// the feedback applied so far
+	Universe struct {
+		words []WordleWord
+		// index maps each word to its position in words, and is shared by
+		// every copy of the universe
+		index map[WordleWord]int
+		// candidates holds the indices of the words which remain, and is
+		// replaced rather than modified so that copies of a universe are
+		// independent
+		candidates                     *BitSet
+		length                         int
+		bitMask                        WordleWord
+		solutionChars, eliminatedChars uint32
+		greens                         WordleWord
+		// minCounts and maxCounts bound the number of times each letter
+		// occurs in the answer
+		minCounts, maxCounts [26]uint8
+		filters              []func(WordleWord) bool
+		count                int
+	}
+)
+
+// NewUniverse creates a universe of words which share the length of the
+// first word
+func NewUniverse(words []WordleWord) Universe {
+	length := DefaultWordLen
+	if len(words) > 0 {
+		length = words[0].Len()
+	}
+	candidates := NewBitSet(len(words))
+	index := make(map[WordleWord]int, len(words))
+	for i, v := range words {
+		candidates.Insert(i)
+		index[v] = i
+	}
+	u := Universe{
+		words:      words,
+		index:      index,
+		candidates: candidates,
+		length:     length,
+		bitMask:    fillMask(allBits, length),
+	}
+	for i := range u.maxCounts {
+		u.maxCounts[i] = uint8(length)
+	}
+	return u.condense()
+}
+
+// Apply narrows the universe by the feedback pattern received for guess
+func (u Universe) Apply(guess WordleWord, pattern WordlePattern) Universe {
+	for i, v := range guess {
+		pattern[i].v = v
+	}
+	return u.ApplyPattern(pattern)
+}
+
+func (u Universe) ApplyPattern(pattern WordlePattern) Universe {
+	present := pattern.PresentChars()
+	u.solutionChars |= present
+	for _, v := range pattern {
+		if v.kind == PatternKindB && v.v&present == 0 {
+			u.eliminatedChars |= v.v
+		}
+	}
+	var counts [26]uint8
+	var capped uint32
+	for i, v := range pattern[:pattern.Len()] {
+		if v.kind == PatternKindG {
+			u.greens[i] = v.v
+		}
+		if v.kind != PatternKindB {
+			counts[bits.TrailingZeros32(v.v)]++
+		} else {
+			capped |= v.v
+		}
+	}
+	for i, v := range counts {
+		u.minCounts[i] = max(u.minCounts[i], v)
+		// a black mark means every occurrence of the letter was marked, so
+		// the answer has exactly as many as were marked green or yellow
+		if capped&(1<<i) != 0 {
+			u.maxCounts[i] = min(u.maxCounts[i], v)
+		}
+	}
+	u.bitMask = u.bitMask.Filter(pattern)
+	return u.condense()
+}
+
+// IsLegalHardModeGuess reports whether w uses every hint revealed so far,
+// and if not, describes the first constraint it violates
+func (u Universe) IsLegalHardModeGuess(w WordleWord) (bool, string) {
+	for i, v := range u.greens {
+		if v != 0 && w[i] != v {
+			return false, fmt.Sprintf("%s letter must be %s", ordinal(i+1), charString(v))
+		}
+	}
+	var counts [26]uint8
+	for _, v := range w[:w.Len()] {
+		counts[bits.TrailingZeros32(v)]++
+	}
+	for i, v := range u.minCounts {
+		if counts[i] < v {
+			if v == 1 {
+				return false, fmt.Sprintf("guess must contain %c", 'A'+i)
+			}
+			return false, fmt.Sprintf("guess must contain %d %cs", v, 'A'+i)
+		}
+	}
+	if c := w.CharSet() & u.eliminatedChars; c != 0 {
+		return false, fmt.Sprintf("%s is not in the word", charString(c&-c))
+	}
+	return true, ""
+}
+
+// HardModeGuesses returns the words of pool that are legal hard mode guesses
+func (u Universe) HardModeGuesses(pool []WordleWord) []WordleWord {
+	legal := make([]WordleWord, 0, len(pool))
+	for _, v := range pool {
+		if ok, _ := u.IsLegalHardModeGuess(v); ok {
+			legal = append(legal, v)
+		}
+	}
+	return legal
+}
+
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", n)
+	}
+}
+
+func charString(c uint32) string {
+	return string(rune('A' + bits.TrailingZeros32(c)))
+}
+
+// condense removes the candidates which no longer match, only visiting the
+// words which remained after the last condense
+func (u Universe) condense() Universe {
+	candidates := u.candidates.Clone()
+	var condensed WordleWord
+	u.candidates.Range(func(i int) bool {
+		if v := u.words[i]; u.Contains(v) {
+			condensed = condensed.Or(v)
+		} else {
+			candidates.Remove(i)
+		}
+		return true
+	})
+	u.candidates = candidates
+	u.bitMask = condensed
+	u.count = candidates.Size()
+	return u
+}
+
+func (u Universe) WithLetter(c uint32, present bool) Universe {
+	if present {
+		u.solutionChars |= c
+	} else {
+		u.eliminatedChars |= c
+		u.bitMask = u.bitMask.And(fillMask(^c, u.length))
+	}
+	return u.condense()
+}
+
+func (u Universe) WithPosition(i int, c uint32, present bool) Universe {
+	if present {
+		u.solutionChars |= c
+		u.bitMask[i] &= c
+	} else {
+		u.bitMask[i] &^= c
+	}
+	return u.condense()
+}
+
+// WithFilter narrows the universe by a predicate for constraints that
+// cannot be expressed as letter masks
+func (u Universe) WithFilter(f func(WordleWord) bool) Universe {
+	u.filters = append(u.filters[:len(u.filters):len(u.filters)], f)
+	return u.condense()
+}
+
+func (u Universe) Contains(v WordleWord) bool {
+	vc := v.CharSet()
+	if !u.bitMask.Match(v) || vc&u.solutionChars != u.solutionChars || vc&u.eliminatedChars != 0 {
+		return false
+	}
+	var counts [26]uint8
+	for _, c := range v[:u.length] {
+		counts[bits.TrailingZeros32(c)]++
+	}
+	for i, c := range counts {
+		if c < u.minCounts[i] || c > u.maxCounts[i] {
+			return false
+		}
+	}
+	for _, f := range u.filters {
+		if !f(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (u Universe) Words() []WordleWord {
+	return u.words
+}
+
+// Len returns the length of the words in the universe
+func (u Universe) Len() int {
+	return u.length
+}
+
+func (u Universe) Count() int {
+	return u.count
+}
+
+func (u Universe) Candidates() []WordleWord {
+	candidates := make([]WordleWord, 0, u.count)
+	u.candidates.Range(func(i int) bool {
+		candidates = append(candidates, u.words[i])
+		return true
+	})
+	return candidates
+}
+
+// AnswerProbability returns the probability that w is the answer under a
+// uniform prior over the remaining candidates
+func (u Universe) AnswerProbability(w WordleWord) float64 {
+	i, ok := u.index[w]
+	if !ok || !u.candidates.Contains(i) {
+		return 0
+	}
+	return 1 / float64(u.count)
+}
+
+func (u Universe) BitMask() WordleWord {
+	return u.bitMask
+}
+
+// Greens returns the letters known to be in place, and is zero elsewhere
+func (u Universe) Greens() WordleWord {
+	return u.greens
+}
+
+// LetterCounts returns the minimum and maximum number of times each letter
+// may occur in the answer
+func (u Universe) LetterCounts() ([26]uint8, [26]uint8) {
+	return u.minCounts, u.maxCounts
+}
+
+// CandidateIndices returns the indices of the remaining words in Words
+func (u Universe) CandidateIndices() []int {
+	return u.candidates.Slice()
+}
+
+// CandidateBytes returns the size of the set of remaining candidates
+func (u Universe) CandidateBytes() int {
+	return u.candidates.Bytes()
+}
+
+func (u Universe) SolutionChars() uint32 {
+	return u.solutionChars
+}
+
+func (u Universe) EliminatedChars() uint32 {
+	return u.eliminatedChars
+}
+
+func CalcExpectedInformationGain(guess WordleWord, universe Universe) float64 {
+	universeSize := 0
+	var avgEndEntropy float64
+	for _, v := range universe.Candidates() {
+		entropy := CalcEntropy(universe.ApplyPattern(v.ComputePattern(guess)).Count())
+		incrSize := float64(universeSize + 1)
+		avgEndEntropy = avgEndEntropy*(float64(universeSize)/incrSize) + entropy/incrSize
+		universeSize++
+	}
+	if universeSize == 0 {
+		return -1
+	}
+	// information gain is entropy start state - entropy of end state
+	return CalcEntropy(universeSize) - avgEndEntropy
+}
+
+func CalcEntropy(count int) float64 {
+	return math.Log2(float64(count))
+}
@@ -0,0 +1,201 @@
+package wordle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func mustWords(t testing.TB, words ...string) []WordleWord {
+	t.Helper()
+	k := make([]WordleWord, 0, len(words))
+	for _, v := range words {
+		w, err := ParseWord(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k = append(k, w)
+	}
+	return k
+}
+
+func TestUniverseDuplicateLetters(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "crane", "eerie", "geese", "label", "llama", "class", "sassy", "abide", "speed", "abbey", "babes", "stool")
+	universe := NewUniverse(words)
+	for _, target := range words {
+		for _, guess := range words {
+			pattern := target.ComputePattern(guess)
+			u := universe.ApplyPattern(pattern)
+			// a black mark on a repeated letter must not eliminate the copy
+			// that is in the target
+			if !u.Contains(target) {
+				t.Fatalf("guess %s eliminated target %s with %s", guess, target, pattern.Compact())
+			}
+			want := 0
+			for _, v := range words {
+				if v.ComputePattern(guess) == pattern {
+					want++
+				}
+			}
+			if got := u.Count(); got != want {
+				t.Fatalf("guess %s target %s: expected %d candidates, got %d", guess, target, want, got)
+			}
+		}
+	}
+}
+
+func TestUniverseSixLetters(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "banana", "bandit", "cancel", "candle", "handle", "little", "settle", "sleeve", "tattoo")
+	universe := NewUniverse(words)
+	if universe.Len() != 6 {
+		t.Fatalf("expected length 6, got %d", universe.Len())
+	}
+	if got := universe.BitMask().Len(); got != 6 {
+		t.Fatalf("expected a 6 letter mask, got %d", got)
+	}
+	for _, target := range words {
+		u := universe
+		// each guess narrows the universe further, exercising condense on
+		// the candidates left by the previous turn
+		for _, guess := range words {
+			pattern := target.ComputePattern(guess)
+			if pattern.Len() != 6 {
+				t.Fatalf("expected a 6 letter pattern, got %d", pattern.Len())
+			}
+			prev := u.Candidates()
+			u = u.ApplyPattern(pattern)
+			var want []WordleWord
+			for _, v := range prev {
+				if v.ComputePattern(guess) == pattern {
+					want = append(want, v)
+				}
+			}
+			if got := u.Candidates(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("guess %s target %s: expected %v, got %v", guess, target, want, got)
+			}
+			if pattern.Solved() {
+				break
+			}
+		}
+		if got := u.Candidates(); len(got) != 1 || got[0] != target {
+			t.Fatalf("expected only %s to remain, got %v", target, got)
+		}
+	}
+}
+
+func TestIsLegalHardModeGuess(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "crane", "geese")
+	universe := NewUniverse(words)
+
+	for _, tc := range []struct {
+		Target string
+		Guess  string
+		Next   string
+		Reason string
+	}{
+		{Target: "crane", Guess: "trace", Next: "grace"},
+		{Target: "crane", Guess: "trace", Next: "craze"},
+		{Target: "crane", Guess: "trace", Next: "beach", Reason: "2nd letter must be R"},
+		{Target: "crane", Guess: "trace", Next: "brake", Reason: "guess must contain C"},
+		{Target: "crane", Guess: "trace", Next: "crate", Reason: "T is not in the word"},
+		{Target: "crane", Guess: "adieu", Next: "stoke", Reason: "guess must contain A"},
+		{Target: "crane", Guess: "adieu", Next: "blame"},
+		{Target: "geese", Guess: "eerie", Next: "geese"},
+		{Target: "geese", Guess: "eerie", Next: "xexxe", Reason: "guess must contain 3 Es"},
+		{Target: "geese", Guess: "eerie", Next: "eeexx", Reason: "5th letter must be E"},
+		{Target: "geese", Guess: "eerie", Next: "eexre", Reason: "R is not in the word"},
+	} {
+		t.Run(tc.Target+" "+tc.Guess+" "+tc.Next, func(t *testing.T) {
+			t.Parallel()
+
+			target := mustWords(t, tc.Target)[0]
+			guess := mustWords(t, tc.Guess)[0]
+			u := universe.ApplyPattern(target.ComputePattern(guess))
+			ok, reason := u.IsLegalHardModeGuess(mustWords(t, tc.Next)[0])
+			if ok != (tc.Reason == "") || reason != tc.Reason {
+				t.Fatalf("expected %t %q, got %t %q", tc.Reason == "", tc.Reason, ok, reason)
+			}
+		})
+	}
+
+	u := universe.ApplyPattern(words[0].ComputePattern(mustWords(t, "trace")[0]))
+	pool := mustWords(t, "beach", "brake", "crane", "grace", "trace")
+	legal := u.HardModeGuesses(pool)
+	if len(legal) != 2 || legal[0] != pool[2] || legal[1] != pool[3] {
+		t.Fatalf("expected CRANE and GRACE, got %v", legal)
+	}
+}
+
+func TestUniverseSnapshots(t *testing.T) {
+	t.Parallel()
+
+	words, err := DefaultWords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := mustWords(t, "stool")[0]
+	// history holds the universe before each guess, which undo restores
+	history := []Universe{NewUniverse(words)}
+	var after []Universe
+	for _, v := range mustWords(t, "crane", "moist", "spool") {
+		u := history[len(history)-1].ApplyPattern(target.ComputePattern(v))
+		history = append(history, u)
+		after = append(after, u)
+	}
+	firstCount := after[0].Count()
+	firstCandidates := after[0].Candidates()
+	// undo twice
+	history = history[:len(history)-2]
+	u := history[len(history)-1]
+	if u.Count() != firstCount || !reflect.DeepEqual(u.Candidates(), firstCandidates) {
+		t.Fatalf("expected the universe after the first guess with %d candidates, got %d", firstCount, u.Count())
+	}
+	if !reflect.DeepEqual(u, after[0]) {
+		t.Fatal("expected the restored universe to equal the snapshot after the first guess")
+	}
+	if u.Count() == after[2].Count() {
+		t.Fatal("expected later guesses to narrow the universe")
+	}
+	// applying a different guess to the restored universe leaves the snapshot
+	// untouched
+	u.ApplyPattern(target.ComputePattern(mustWords(t, "stool")[0]))
+	if u.Count() != firstCount || !reflect.DeepEqual(u.Candidates(), firstCandidates) {
+		t.Fatal("expected the snapshot to be unchanged by later guesses")
+	}
+}
+
+func TestAnswerProbability(t *testing.T) {
+	t.Parallel()
+
+	words := mustWords(t, "bakes", "cakes", "fakes", "makes", "takes")
+	guess := mustWords(t, "bakes")[0]
+	pattern, err := ParseWordlePattern(guess, "bgggg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	universe := NewUniverse(words).Apply(guess, pattern)
+
+	for _, tc := range []struct {
+		Word string
+		P    float64
+	}{
+		{Word: "cakes", P: 0.25},
+		{Word: "takes", P: 0.25},
+		{Word: "bakes", P: 0},
+		{Word: "lakes", P: 0},
+		{Word: "cflmz", P: 0},
+	} {
+		t.Run(tc.Word, func(t *testing.T) {
+			t.Parallel()
+
+			if p := universe.AnswerProbability(mustWords(t, tc.Word)[0]); p != tc.P {
+				t.Fatalf("expected %v, got %v", tc.P, p)
+			}
+		})
+	}
+}
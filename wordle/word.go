@@ -0,0 +1,359 @@
+// Package wordle implements the Wordle feedback model, candidate filtering,
+// and guess scoring used by the wordlebot CLI.
+package wordle
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrWordLen     = errors.New("Error word length")
+	ErrWordChar    = errors.New("Error word char")
+	ErrPatternLen  = errors.New("Error pattern length")
+	ErrPatternChar = errors.New("Error pattern char, expected B, Y, G, or .")
+)
+
+const (
+	// MaxWordLen is the longest supported word, where shorter words leave the
+	// remaining positions zero
+	MaxWordLen = 8
+	// DefaultWordLen is the length of the embedded word lists
+	DefaultWordLen = 5
+)
+
+type (
+	WordleWord [MaxWordLen]uint32
+
+	PatternKind byte
+
+	WordlePatternLetter struct {
+		v    uint32
+		kind PatternKind
+	}
+
+	WordlePattern [MaxWordLen]WordlePatternLetter
+)
+
+const (
+	PatternKindB PatternKind = iota
+	PatternKindY
+	PatternKindG
+)
+
+// Len returns the number of letters in the word, which is the number of
+// leading nonzero positions
+func (w WordleWord) Len() int {
+	for i, v := range w {
+		if v == 0 {
+			return i
+		}
+	}
+	return len(w)
+}
+
+func (w WordleWord) String() string {
+	var b strings.Builder
+	for _, v := range w[:w.Len()] {
+		b.WriteByte(byte(bits.TrailingZeros32(v)) + 'A')
+	}
+	return b.String()
+}
+
+func (w WordleWord) StringMask() string {
+	masks := make([]string, 0, w.Len())
+	for _, v := range w[:w.Len()] {
+		masks = append(masks, fmt.Sprintf("%026b", v))
+	}
+	return strings.Join(masks, ",")
+}
+
+func (w WordleWord) Compare(other WordleWord) int {
+	for i := range w {
+		if c := cmp.Compare(w[i], other[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func (w WordleWord) Or(other WordleWord) WordleWord {
+	for i := range w {
+		w[i] |= other[i]
+	}
+	return w
+}
+
+func (w WordleWord) And(other WordleWord) WordleWord {
+	for i := range w {
+		w[i] &= other[i]
+	}
+	return w
+}
+
+func (w WordleWord) Match(other WordleWord) bool {
+	return w.And(other) == other
+}
+
+func (w WordleWord) CharSet() uint32 {
+	var c uint32
+	for _, v := range w {
+		c |= v
+	}
+	return c
+}
+
+// Slice returns the first n positions of the word
+func (w WordleWord) Slice(n int) []uint32 {
+	return slices.Clone(w[:n])
+}
+
+// fillMask returns a word with mask in each of the first n positions
+func fillMask(mask uint32, n int) WordleWord {
+	var w WordleWord
+	for i := range n {
+		w[i] = mask
+	}
+	return w
+}
+
+func (w WordleWord) Filter(pattern WordlePattern) WordleWord {
+	present := pattern.PresentChars()
+	n := pattern.Len()
+	for i, v := range pattern[:n] {
+		switch v.kind {
+		case PatternKindB:
+			var mask uint32 = ^v.v
+			if v.v&present != 0 {
+				// a B on a letter marked Y or G elsewhere only means there are no
+				// further copies, so the letter is excluded here but not everywhere
+				w[i] &= mask
+			} else {
+				w = w.And(fillMask(mask, n))
+			}
+		case PatternKindY:
+			var mask uint32 = ^v.v
+			w[i] &= mask
+		case PatternKindG:
+			var mask uint32 = v.v
+			w[i] = mask
+		}
+	}
+	return w
+}
+
+func (w WordleWord) ComputePattern(other WordleWord) WordlePattern {
+	// greens are marked first, and the target letters they do not consume are
+	// then assigned to yellows from left to right, so a repeated guess letter
+	// is only marked as many times as it appears in the target
+	var remaining [26]int8
+	var pattern WordlePattern
+	n := w.Len()
+	for i, v := range w[:n] {
+		c := other[i]
+		pattern[i].v = c
+		if c == v {
+			pattern[i].kind = PatternKindG
+		} else {
+			remaining[bits.TrailingZeros32(v)]++
+		}
+	}
+	for i, v := range pattern[:n] {
+		if v.kind == PatternKindG {
+			continue
+		}
+		k := bits.TrailingZeros32(v.v)
+		if remaining[k] > 0 {
+			remaining[k]--
+			pattern[i].kind = PatternKindY
+		} else {
+			pattern[i].kind = PatternKindB
+		}
+	}
+	return pattern
+}
+
+// Len returns the number of letters in the pattern
+func (p WordlePattern) Len() int {
+	for i, v := range p {
+		if v.v == 0 {
+			return i
+		}
+	}
+	return len(p)
+}
+
+// Solved reports whether every letter of the pattern is G
+func (p WordlePattern) Solved() bool {
+	n := p.Len()
+	for _, v := range p[:n] {
+		if v.kind != PatternKindG {
+			return false
+		}
+	}
+	return n > 0
+}
+
+func (p WordlePattern) PresentChars() uint32 {
+	var present uint32
+	for _, v := range p {
+		if v.kind != PatternKindB {
+			present |= v.v
+		}
+	}
+	return present
+}
+
+func (p WordlePattern) String() string {
+	var b strings.Builder
+	for i, v := range p[:p.Len()] {
+		if i != 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteByte(byte(bits.TrailingZeros32(v.v)) + 'A')
+		b.WriteByte(':')
+		switch v.kind {
+		case PatternKindB:
+			b.WriteByte('B')
+		case PatternKindY:
+			b.WriteByte('Y')
+		case PatternKindG:
+			b.WriteByte('G')
+		}
+	}
+	return b.String()
+}
+
+const (
+	// NumPatterns bounds the pattern code of a word of up to MaxWordLen
+	// letters, which is 3^MaxWordLen
+	NumPatterns = 6561
+)
+
+// PatternCount returns the number of distinct patterns of a word of length n
+func PatternCount(n int) int {
+	c := 1
+	for range n {
+		c *= 3
+	}
+	return c
+}
+
+func (p WordlePattern) Compact() string {
+	var b strings.Builder
+	for _, v := range p[:p.Len()] {
+		switch v.kind {
+		case PatternKindB:
+			b.WriteByte('B')
+		case PatternKindY:
+			b.WriteByte('Y')
+		case PatternKindG:
+			b.WriteByte('G')
+		}
+	}
+	return b.String()
+}
+
+func (p WordlePattern) Colored() string {
+	var b strings.Builder
+	for _, v := range p[:p.Len()] {
+		switch v.kind {
+		case PatternKindB:
+			b.WriteString("\x1b[30;47m")
+		case PatternKindY:
+			b.WriteString("\x1b[30;43m")
+		case PatternKindG:
+			b.WriteString("\x1b[30;42m")
+		}
+		b.WriteByte(' ')
+		b.WriteByte(byte(bits.TrailingZeros32(v.v)) + 'A')
+		b.WriteByte(' ')
+	}
+	b.WriteString("\x1b[0m")
+	return b.String()
+}
+
+// Render returns the pattern as board tiles if colored is set, and otherwise
+// as letter:mark text
+func (p WordlePattern) Render(colored bool) string {
+	if colored {
+		return p.Colored()
+	}
+	return p.String()
+}
+
+// Code returns the pattern as a base-3 number with the first letter as the
+// least significant digit, where B is 0, Y is 1, and G is 2
+func (p WordlePattern) Code() int {
+	code := 0
+	for i := p.Len() - 1; i >= 0; i-- {
+		code = code*3 + int(p[i].kind)
+	}
+	return code
+}
+
+// ParseWord parses a word of up to MaxWordLen letters
+func ParseWord(s string) (WordleWord, error) {
+	if len(s) == 0 || len(s) > MaxWordLen {
+		return WordleWord{}, ErrWordLen
+	}
+	var w WordleWord
+	for i := range len(s) {
+		c := s[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		// checked byte by byte rather than after strings.ToUpper, which may
+		// change the length of non ascii input
+		if c < 'A' || c > 'Z' {
+			return WordleWord{}, ErrWordChar
+		}
+		w[i] = 1 << (c - 'A')
+	}
+	return w, nil
+}
+
+// ParseWordLen parses a word which must have n letters
+func ParseWordLen(s string, n int) (WordleWord, error) {
+	if len(s) != n {
+		return WordleWord{}, ErrWordLen
+	}
+	return ParseWord(s)
+}
+
+func ParsePattern(guess string, feedback string) (WordlePattern, error) {
+	w, err := ParseWord(guess)
+	if err != nil {
+		return WordlePattern{}, err
+	}
+	return ParseWordlePattern(w, feedback)
+}
+
+func ParseWordlePattern(guess WordleWord, s string) (WordlePattern, error) {
+	if len(s) != guess.Len() {
+		return WordlePattern{}, fmt.Errorf("%w: got %d, expected %d", ErrPatternLen, len(s), guess.Len())
+	}
+	var pattern WordlePattern
+	for i, v := range guess[:guess.Len()] {
+		var kind PatternKind
+		switch s[i] {
+		case 'B', 'b', '.':
+			kind = PatternKindB
+		case 'Y', 'y':
+			kind = PatternKindY
+		case 'G', 'g':
+			kind = PatternKindG
+		default:
+			return WordlePattern{}, fmt.Errorf("%w: %q at position %d", ErrPatternChar, s[i], i+1)
+		}
+		pattern[i] = WordlePatternLetter{
+			v:    v,
+			kind: kind,
+		}
+	}
+	return pattern, nil
+}
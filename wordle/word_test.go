@@ -0,0 +1,190 @@
+package wordle
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseWord(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name string
+		Word string
+		Want string
+		Err  error
+	}{
+		{Name: "lower", Word: "crane", Want: "CRANE"},
+		{Name: "upper", Word: "CRANE", Want: "CRANE"},
+		{Name: "mixed", Word: "CrAnE", Want: "CRANE"},
+		{Name: "range ends", Word: "azAZ", Want: "AZAZ"},
+		{Name: "max length", Word: "abcdefgh", Want: "ABCDEFGH"},
+		{Name: "empty", Word: "", Err: ErrWordLen},
+		{Name: "too long", Word: "abcdefghi", Err: ErrWordLen},
+		{Name: "digit", Word: "cr4ne", Err: ErrWordChar},
+		{Name: "digits", Word: "12345", Err: ErrWordChar},
+		{Name: "space", Word: "cr ne", Err: ErrWordChar},
+		{Name: "leading space", Word: " crane", Err: ErrWordChar},
+		{Name: "below A", Word: "@rane", Err: ErrWordChar},
+		{Name: "above Z", Word: "[rane", Err: ErrWordChar},
+		{Name: "below a", Word: "`rane", Err: ErrWordChar},
+		{Name: "above z", Word: "{rane", Err: ErrWordChar},
+		{Name: "accented", Word: "caf\xc3\xa9", Err: ErrWordChar},
+		{Name: "latin1 accented", Word: "caf\xe9s", Err: ErrWordChar},
+		{Name: "nul", Word: "cr\x00ne", Err: ErrWordChar},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			w, err := ParseWord(tc.Word)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := w.String(); got != tc.Want {
+				t.Fatalf("expected %s, got %s", tc.Want, got)
+			}
+			if w.Len() != len(tc.Want) {
+				t.Fatalf("expected length %d, got %d", len(tc.Want), w.Len())
+			}
+		})
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Guess    string
+		Feedback string
+		Compact  string
+		Err      error
+	}{
+		{Guess: "crane", Feedback: "GYBBY", Compact: "GYBBY"},
+		{Guess: "crane", Feedback: "..y.g", Compact: "BBYBG"},
+		{Guess: "crane", Feedback: "gGbBy", Compact: "GGBBY"},
+		{Guess: "crane", Feedback: "GYBB", Err: ErrPatternLen},
+		{Guess: "crane", Feedback: "GYBBYY", Err: ErrPatternLen},
+		{Guess: "crane", Feedback: "", Err: ErrPatternLen},
+		{Guess: "crane", Feedback: "GYXBY", Err: ErrPatternChar},
+		{Guess: "crane", Feedback: "GY BY", Err: ErrPatternChar},
+		{Guess: "cr4ne", Feedback: "GYBBY", Err: ErrWordChar},
+		{Guess: "cranes", Feedback: "GYBBYB", Compact: "GYBBYB"},
+	} {
+		t.Run(tc.Guess+" "+tc.Feedback, func(t *testing.T) {
+			t.Parallel()
+
+			pattern, err := ParsePattern(tc.Guess, tc.Feedback)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+			if err != nil {
+				return
+			}
+			if c := pattern.Compact(); c != tc.Compact {
+				t.Fatalf("expected %s, got %s", tc.Compact, c)
+			}
+			letters := ""
+			for _, v := range pattern.Marks() {
+				letters += v.Letter
+			}
+			if letters != strings.ToUpper(tc.Guess) {
+				t.Fatalf("expected pattern letters %s, got %s", strings.ToUpper(tc.Guess), letters)
+			}
+		})
+	}
+}
+
+func TestComputePattern(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Target  string
+		Guess   string
+		Pattern string
+	}{
+		{Target: "crane", Guess: "crane", Pattern: "GGGGG"},
+		{Target: "crane", Guess: "eerie", Pattern: "BBYBG"},
+		{Target: "label", Guess: "llama", Pattern: "GYYBB"},
+		{Target: "class", Guess: "sassy", Pattern: "YYBGB"},
+		{Target: "abide", Guess: "speed", Pattern: "BBYBY"},
+		{Target: "eerie", Guess: "geese", Pattern: "BGYBG"},
+		{Target: "abbey", Guess: "babes", Pattern: "YYGGB"},
+		{Target: "stool", Guess: "ooooo", Pattern: "BBGGB"},
+		{Target: "wrung", Guess: "aaaaa", Pattern: "BBBBB"},
+		{Target: "geese", Guess: "eerie", Pattern: "YGBBG"},
+	} {
+		t.Run(tc.Target+" "+tc.Guess, func(t *testing.T) {
+			t.Parallel()
+
+			target, err := ParseWord(tc.Target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			guess, err := ParseWord(tc.Guess)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pattern := target.ComputePattern(guess)
+			if c := pattern.Compact(); c != tc.Pattern {
+				t.Fatalf("expected %s, got %s", tc.Pattern, c)
+			}
+			parsed, err := ParseWordlePattern(guess, tc.Pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed != pattern {
+				t.Fatalf("expected the parsed pattern to equal the computed pattern")
+			}
+		})
+	}
+}
+
+func TestPatternRender(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Guess    string
+		Feedback string
+		Colored  bool
+		Render   string
+	}{
+		{
+			Guess:    "crane",
+			Feedback: "GYBBB",
+			Colored:  true,
+			Render:   "\x1b[30;42m C \x1b[30;43m R \x1b[30;47m A \x1b[30;47m N \x1b[30;47m E \x1b[0m",
+		},
+		{
+			Guess:    "crane",
+			Feedback: "GYBBB",
+			Render:   "C:G R:Y A:B N:B E:B",
+		},
+		{
+			Guess:    "abc",
+			Feedback: "GGG",
+			Colored:  true,
+			Render:   "\x1b[30;42m A \x1b[30;42m B \x1b[30;42m C \x1b[0m",
+		},
+		{
+			Guess:    "abc",
+			Feedback: "YBY",
+			Render:   "A:Y B:B C:Y",
+		},
+	} {
+		t.Run(tc.Guess+" "+tc.Feedback, func(t *testing.T) {
+			t.Parallel()
+
+			pattern, err := ParsePattern(tc.Guess, tc.Feedback)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := pattern.Render(tc.Colored); got != tc.Render {
+				t.Fatalf("expected %q, got %q", tc.Render, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package wordle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var ErrNonAlphaPolicy = errors.New("Unknown nonalpha policy, expected reject, strip, or skip")
+
+type (
+	LoadOpts struct {
+		// StripAnnotations is the set of annotation characters removed from the
+		// end of each entry
+		StripAnnotations string
+		// Len is the required word length, or 0 to use the most common length
+		// of the entries
+		Len int
+		// NonAlpha is the handling of entries with characters other than
+		// letters, such as apostrophes or hyphens
+		NonAlpha NonAlphaPolicy
+	}
+
+	NonAlphaPolicy int
+
+	LoadReport struct {
+		Len        int
+		Kept       int
+		Normalized int
+		Stripped   int
+		NonAlpha   int
+		Duplicates int
+		Rejected   []RejectedEntry
+	}
+
+	RejectedEntry struct {
+		Entry int
+		Word  string
+		Err   error
+	}
+)
+
+const (
+	DefaultStripAnnotations = "*"
+)
+
+const (
+	// NonAlphaReject rejects entries with non letter characters
+	NonAlphaReject NonAlphaPolicy = iota
+	// NonAlphaStrip removes non letter characters and keeps the remainder
+	NonAlphaStrip
+	// NonAlphaSkip drops entries with non letter characters
+	NonAlphaSkip
+)
+
+func ParseNonAlphaPolicy(s string) (NonAlphaPolicy, error) {
+	switch s {
+	case "reject":
+		return NonAlphaReject, nil
+	case "strip":
+		return NonAlphaStrip, nil
+	case "skip":
+		return NonAlphaSkip, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrNonAlphaPolicy, s)
+	}
+}
+
+func isNonAlpha(r rune) bool {
+	return r < 'a' || r > 'z'
+}
+
+// LoadWordList normalizes and parses entries into a sorted list of unique
+// words of a single length
+func LoadWordList(entries []string, opts LoadOpts) ([]WordleWord, LoadReport) {
+	var report LoadReport
+	type normalizedEntry struct {
+		n        int
+		s        string
+		stripped bool
+	}
+	normalized := make([]normalizedEntry, 0, len(entries))
+	lengths := map[int]int{}
+	for n, i := range entries {
+		s := strings.ToLower(strings.TrimSpace(i))
+		if s != i {
+			report.Normalized++
+		}
+		if opts.StripAnnotations != "" {
+			if k := strings.TrimRight(s, opts.StripAnnotations); k != s {
+				s = strings.TrimSpace(k)
+				report.Stripped++
+			}
+		}
+		stripped := false
+		if opts.NonAlpha != NonAlphaReject && strings.IndexFunc(s, isNonAlpha) >= 0 {
+			report.NonAlpha++
+			if opts.NonAlpha == NonAlphaSkip {
+				continue
+			}
+			s = strings.Map(func(r rune) rune {
+				if unicode.IsLetter(r) {
+					return r
+				}
+				return -1
+			}, s)
+			stripped = true
+		}
+		normalized = append(normalized, normalizedEntry{
+			n:        n,
+			s:        s,
+			stripped: stripped,
+		})
+		lengths[len(s)]++
+	}
+	length := opts.Len
+	if length == 0 {
+		length = commonLength(lengths)
+	}
+	words := make([]WordleWord, 0, len(normalized))
+	seen := make(map[WordleWord]struct{}, len(normalized))
+	for _, i := range normalized {
+		if i.stripped && len(i.s) != length {
+			// entries are only kept if stripping leaves a word of the right length
+			continue
+		}
+		var w WordleWord
+		var err error
+		if len(i.s) != length {
+			err = fmt.Errorf("%w: expected %d letters", ErrWordLen, length)
+		} else {
+			w, err = ParseWord(i.s)
+		}
+		if err != nil {
+			report.Rejected = append(report.Rejected, RejectedEntry{
+				Entry: i.n + 1,
+				Word:  entries[i.n],
+				Err:   err,
+			})
+			continue
+		}
+		if _, ok := seen[w]; ok {
+			report.Duplicates++
+			continue
+		}
+		seen[w] = struct{}{}
+		words = append(words, w)
+	}
+	slices.SortFunc(words, WordleWord.Compare)
+	report.Len = length
+	report.Kept = len(words)
+	return words, report
+}
+
+// commonLength returns the most common length of a supported word, preferring
+// the default length and then the shorter length on ties
+func commonLength(lengths map[int]int) int {
+	best, bestCount := DefaultWordLen, lengths[DefaultWordLen]
+	for n := 1; n <= MaxWordLen; n++ {
+		if lengths[n] > bestCount {
+			best, bestCount = n, lengths[n]
+		}
+	}
+	return best
+}
+
+func LoadWords(entries []string) ([]WordleWord, error) {
+	words, report := LoadWordList(entries, LoadOpts{
+		StripAnnotations: DefaultStripAnnotations,
+	})
+	if len(report.Rejected) > 0 {
+		v := report.Rejected[0]
+		return nil, fmt.Errorf("entry %d %q: %w", v.Entry, v.Word, v.Err)
+	}
+	return words, nil
+}
+
+func LoadWordsBytes(entries [][]byte) ([]WordleWord, error) {
+	strs := make([]string, 0, len(entries))
+	for _, i := range entries {
+		strs = append(strs, string(i))
+	}
+	return LoadWords(strs)
+}
+
+// MergeWords returns the sorted union of two sorted word lists
+func MergeWords(a, b []WordleWord) []WordleWord {
+	merged := make([]WordleWord, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		switch c := a[0].Compare(b[0]); {
+		case c < 0:
+			merged = append(merged, a[0])
+			a = a[1:]
+		case c > 0:
+			merged = append(merged, b[0])
+			b = b[1:]
+		default:
+			merged = append(merged, a[0])
+			a = a[1:]
+			b = b[1:]
+		}
+	}
+	merged = append(merged, a...)
+	return append(merged, b...)
+}
+
+// WordsHash returns a content hash of a word list in its given order, which
+// for loaded lists is the canonical sorted order
+func WordsHash(words []WordleWord) string {
+	h := sha256.New()
+	for _, v := range words {
+		io.WriteString(h, v.String())
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (r LoadReport) Write(w io.Writer, name string) {
+	fmt.Fprintf(w, "%s: %d letters, %d kept, %d normalized, %d stripped, %d nonalpha, %d duplicates, %d rejected\n", name, r.Len, r.Kept, r.Normalized, r.Stripped, r.NonAlpha, r.Duplicates, len(r.Rejected))
+	for _, v := range r.Rejected {
+		fmt.Fprintf(w, "%s: entry %d %q: %v\n", name, v.Entry, v.Word, v.Err)
+	}
+}
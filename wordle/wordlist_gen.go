@@ -0,0 +1,8 @@
+// Code generated by genwordlist from wordlist.json; DO NOT EDIT.
+
+package wordle
+
+const (
+	embeddedWordlistCount    = 2309
+	embeddedWordlistChecksum = "349ae9898c3be0cca0276215b537b81b6820955e4b24ea2e714051eb0194b18c"
+)
@@ -0,0 +1,160 @@
+package wordle
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func wordStrings(words []WordleWord) []string {
+	s := make([]string, 0, len(words))
+	for _, v := range words {
+		s = append(s, v.String())
+	}
+	return s
+}
+
+func TestLoadWordList(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name     string
+		Entries  []string
+		Opts     LoadOpts
+		Expected []string
+		Report   LoadReport
+		Rejected []RejectedEntry
+	}{
+		{
+			Name:     "kept",
+			Entries:  []string{"rebut", "cigar"},
+			Expected: []string{"CIGAR", "REBUT"},
+			Report:   LoadReport{Len: 5, Kept: 2},
+		},
+		{
+			Name:     "normalized",
+			Entries:  []string{" rebut", "CIGAR", "sissy"},
+			Expected: []string{"CIGAR", "REBUT", "SISSY"},
+			Report:   LoadReport{Len: 5, Kept: 3, Normalized: 2},
+		},
+		{
+			Name:     "stripped annotations",
+			Entries:  []string{"rebut*", "cigar **", "sissy"},
+			Opts:     LoadOpts{StripAnnotations: DefaultStripAnnotations},
+			Expected: []string{"CIGAR", "REBUT", "SISSY"},
+			Report:   LoadReport{Len: 5, Kept: 3, Stripped: 2},
+		},
+		{
+			Name:     "skipped nonalpha",
+			Entries:  []string{"rebut", "can't", "x-ray", "cigar"},
+			Opts:     LoadOpts{NonAlpha: NonAlphaSkip},
+			Expected: []string{"CIGAR", "REBUT"},
+			Report:   LoadReport{Len: 5, Kept: 2, NonAlpha: 2},
+		},
+		{
+			Name:     "stripped nonalpha",
+			Entries:  []string{"rebut", "yo-yos", "it's", "cigar"},
+			Opts:     LoadOpts{NonAlpha: NonAlphaStrip},
+			Expected: []string{"CIGAR", "REBUT", "YOYOS"},
+			Report:   LoadReport{Len: 5, Kept: 3, NonAlpha: 2},
+		},
+		{
+			Name:     "duplicates",
+			Entries:  []string{"rebut", "cigar", "REBUT", "rebut", "cigar*"},
+			Opts:     LoadOpts{StripAnnotations: DefaultStripAnnotations},
+			Expected: []string{"CIGAR", "REBUT"},
+			Report:   LoadReport{Len: 5, Kept: 2, Normalized: 1, Stripped: 1, Duplicates: 3},
+		},
+		{
+			Name:     "invalid",
+			Entries:  []string{"rebut", "r3but", "humphs", "cigar"},
+			Expected: []string{"CIGAR", "REBUT"},
+			Report:   LoadReport{Len: 5, Kept: 2},
+			Rejected: []RejectedEntry{
+				{Entry: 2, Word: "r3but", Err: ErrWordChar},
+				{Entry: 3, Word: "humphs", Err: ErrWordLen},
+			},
+		},
+		{
+			Name:     "required length",
+			Entries:  []string{"rebut", "cat", "dog"},
+			Opts:     LoadOpts{Len: 5},
+			Expected: []string{"REBUT"},
+			Report:   LoadReport{Len: 5, Kept: 1},
+			Rejected: []RejectedEntry{
+				{Entry: 2, Word: "cat", Err: ErrWordLen},
+				{Entry: 3, Word: "dog", Err: ErrWordLen},
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			words, report := LoadWordList(tc.Entries, tc.Opts)
+			if got := wordStrings(words); !slices.Equal(got, tc.Expected) {
+				t.Fatalf("expected words %v, got %v", tc.Expected, got)
+			}
+			rejected := report.Rejected
+			report.Rejected = nil
+			if report.Len != tc.Report.Len || report.Kept != tc.Report.Kept || report.Normalized != tc.Report.Normalized || report.Stripped != tc.Report.Stripped || report.NonAlpha != tc.Report.NonAlpha || report.Duplicates != tc.Report.Duplicates {
+				t.Fatalf("expected report %+v, got %+v", tc.Report, report)
+			}
+			if len(rejected) != len(tc.Rejected) {
+				t.Fatalf("expected %d rejected, got %+v", len(tc.Rejected), rejected)
+			}
+			for i, v := range tc.Rejected {
+				if rejected[i].Entry != v.Entry || rejected[i].Word != v.Word || !errors.Is(rejected[i].Err, v.Err) {
+					t.Fatalf("expected rejected %+v, got %+v", v, rejected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadWords(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name     string
+		Entries  []string
+		Expected []string
+		Err      error
+	}{
+		{
+			Name:     "mixed case",
+			Entries:  []string{"Rebut", "CIGAR", "sIsSy"},
+			Expected: []string{"CIGAR", "REBUT", "SISSY"},
+		},
+		{
+			Name:     "duplicates",
+			Entries:  []string{"rebut", "REBUT", "cigar", " Rebut ", "cigar*"},
+			Expected: []string{"CIGAR", "REBUT"},
+		},
+		{
+			Name:    "invalid",
+			Entries: []string{"rebut", "r3but"},
+			Err:     ErrWordChar,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			entries := make([][]byte, 0, len(tc.Entries))
+			for _, v := range tc.Entries {
+				entries = append(entries, []byte(v))
+			}
+			for name, load := range map[string]func() ([]WordleWord, error){
+				"LoadWords":      func() ([]WordleWord, error) { return LoadWords(tc.Entries) },
+				"LoadWordsBytes": func() ([]WordleWord, error) { return LoadWordsBytes(entries) },
+			} {
+				words, err := load()
+				if !errors.Is(err, tc.Err) {
+					t.Fatalf("%s: expected error %v, got %v", name, tc.Err, err)
+				}
+				if got := wordStrings(words); !slices.Equal(got, tc.Expected) {
+					t.Fatalf("%s: expected words %v, got %v", name, tc.Expected, got)
+				}
+			}
+		})
+	}
+}
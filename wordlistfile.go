@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+var ErrStdinWordList = errors.New("Error a word list can only be read from stdin by -simulate-all, -serve, -quordle-openers, -calc-info-gain, or -precompute")
+
+// CheckStdinWordList rejects word lists read from stdin, which is read to the
+// end, unless the mode is non interactive and does not also read stdin
+func CheckStdinWordList(interactive bool, names ...string) error {
+	if interactive && slices.Contains(names, "-") {
+		return ErrStdinWordList
+	}
+	return nil
+}
+
+// ReadWordList reads a JSON array or newline delimited word list from a file,
+// or from stdin when name is "-", and reports every invalid entry by line
+func ReadWordList(name string, opts wordle.LoadOpts) ([]wordle.WordleWord, wordle.LoadReport, error) {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, wordle.LoadReport{}, fmt.Errorf("Failed reading word list %s: %w", name, err)
+	}
+	var entries []string
+	var lines []int
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		entries, lines, err = parseJSONWordList(data)
+		if err != nil {
+			return nil, wordle.LoadReport{}, fmt.Errorf("Invalid word list %s: %w", name, err)
+		}
+	} else {
+		for n, i := range strings.Split(string(data), "\n") {
+			if i = strings.TrimSpace(i); i != "" {
+				entries = append(entries, i)
+				lines = append(lines, n+1)
+			}
+		}
+	}
+	words, report := wordle.LoadWordList(entries, opts)
+	if len(report.Rejected) > 0 {
+		errs := make([]error, 0, len(report.Rejected))
+		for _, v := range report.Rejected {
+			errs = append(errs, fmt.Errorf("line %d: word '%s': %w", lines[v.Entry-1], v.Word, v.Err))
+		}
+		return nil, report, fmt.Errorf("Invalid word list %s:\n%w", name, errors.Join(errs...))
+	}
+	return words, report, nil
+}
+
+// parseJSONWordList decodes a JSON array of strings along with the line each
+// entry starts on
+func parseJSONWordList(data []byte) ([]string, []int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	var entries []string
+	var lines []int
+	line, counted := 1, 0
+	for dec.More() {
+		// the offset after the previous token precedes any whitespace before
+		// the entry, which is skipped to find its line
+		offset := int(dec.InputOffset())
+		for offset < len(data) && strings.IndexByte(" \t\r\n,", data[offset]) >= 0 {
+			offset++
+		}
+		line += bytes.Count(data[counted:offset], []byte{'\n'})
+		counted = offset
+		var s string
+		if err := dec.Decode(&s); err != nil {
+			return nil, nil, fmt.Errorf("line %d: entry %d: %w", line, len(entries)+1, err)
+		}
+		entries = append(entries, s)
+		lines = append(lines, line)
+	}
+	if _, err := dec.Token(); err != nil {
+		line += bytes.Count(data[counted:], []byte{'\n'})
+		return nil, nil, fmt.Errorf("line %d: %w", line, err)
+	}
+	return entries, lines, nil
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/wordlebot/wordle"
+)
+
+func TestReadWordList(t *testing.T) {
+	t.Parallel()
+
+	var long strings.Builder
+	for range 212 {
+		long.WriteString("cigar\n")
+	}
+	long.WriteString("abcdef\n")
+
+	for _, tc := range []struct {
+		Name     string
+		Data     string
+		Expected []string
+		Msg      string
+	}{
+		{
+			Name:     "newline delimited",
+			Data:     "rebut\n\ncigar\n  sissy  \nREBUT\n",
+			Expected: []string{"CIGAR", "REBUT", "SISSY"},
+		},
+		{
+			Name:     "json array",
+			Data:     "[\n  \"rebut\",\n  \"cigar\", \"rebut\"\n]\n",
+			Expected: []string{"CIGAR", "REBUT"},
+		},
+		{
+			Name:     "annotations",
+			Data:     "cigar*\nrebut\n",
+			Expected: []string{"CIGAR", "REBUT"},
+		},
+		{
+			Name: "bad length",
+			Data: long.String(),
+			Msg:  "line 213: word 'abcdef': Error word length",
+		},
+		{
+			Name: "every bad line",
+			Data: "cigar\nr3but\nsissy\nhumphs\n",
+			Msg:  "line 2: word 'r3but': Error word char\nline 4: word 'humphs': Error word length",
+		},
+		{
+			Name: "json bad entry",
+			Data: "[\n  \"cigar\",\n\n  \"r3but\"\n]",
+			Msg:  "line 4: word 'r3but': Error word char",
+		},
+		{
+			Name: "json non string",
+			Data: "[\n  \"cigar\",\n  12345\n]",
+			Msg:  "line 3: entry 2",
+		},
+		{
+			Name: "json unterminated",
+			Data: "[\n  \"cigar\"\n",
+			Msg:  "Invalid word list",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			name := filepath.Join(t.TempDir(), "words.txt")
+			if err := os.WriteFile(name, []byte(tc.Data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			words, _, err := ReadWordList(name, wordle.LoadOpts{
+				StripAnnotations: wordle.DefaultStripAnnotations,
+			})
+			if tc.Msg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Msg) {
+					t.Fatalf("expected error containing %q, got %v", tc.Msg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, v := range words {
+				got = append(got, v.String())
+			}
+			if !slices.Equal(got, tc.Expected) {
+				t.Fatalf("expected %v, got %v", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckStdinWordList(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		Name        string
+		Interactive bool
+		Names       []string
+		Err         error
+	}{
+		{Name: "interactive stdin wordlist", Interactive: true, Names: []string{"-", ""}, Err: ErrStdinWordList},
+		{Name: "interactive stdin guesses", Interactive: true, Names: []string{"", "-"}, Err: ErrStdinWordList},
+		{Name: "interactive files", Interactive: true, Names: []string{"words.txt", "guesses.txt"}},
+		{Name: "non interactive stdin", Interactive: false, Names: []string{"-", ""}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := CheckStdinWordList(tc.Interactive, tc.Names...); !errors.Is(err, tc.Err) {
+				t.Fatalf("expected error %v, got %v", tc.Err, err)
+			}
+		})
+	}
+}
+
+func TestReadWordListNonAlpha(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join("testdata", "nonalpha.txt")
+
+	for _, tc := range []struct {
+		Policy   string
+		Expected []string
+		Msg      string
+	}{
+		{
+			Policy: "reject",
+			Msg:    "line 2: word 'can't': Error word char\nline 3: word 'yo-yos': Error word length: expected 5 letters\nline 5: word 'x-ray': Error word char",
+		},
+		{
+			// only stripped entries that still have 5 letters are kept
+			Policy:   "strip",
+			Expected: []string{"CIGAR", "REBUT", "YOYOS"},
+		},
+		{
+			Policy:   "skip",
+			Expected: []string{"CIGAR", "REBUT"},
+		},
+	} {
+		t.Run(tc.Policy, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := wordle.ParseNonAlphaPolicy(tc.Policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			words, _, err := ReadWordList(name, wordle.LoadOpts{
+				NonAlpha: policy,
+			})
+			if tc.Msg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Msg) {
+					t.Fatalf("expected error containing %q, got %v", tc.Msg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(words))
+			for _, v := range words {
+				got = append(got, v.String())
+			}
+			if !slices.Equal(got, tc.Expected) {
+				t.Fatalf("expected %v, got %v", tc.Expected, got)
+			}
+		})
+	}
+}